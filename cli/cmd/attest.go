@@ -7,15 +7,18 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
-	"github.com/ContainerSolutions/argus/cli/pkg/attester"
+	"github.com/ContainerSolutions/argus/cli/pkg/engine"
 	"github.com/ContainerSolutions/argus/cli/pkg/results"
 	"github.com/ContainerSolutions/argus/cli/pkg/storage"
-	"github.com/ContainerSolutions/argus/cli/pkg/utils"
 
 	"github.com/spf13/cobra"
 )
 
+var parallelism int
+var timeout time.Duration
+
 // attestCmd represents the attest command
 var attestCmd = &cobra.Command{
 	Use:   "attest",
@@ -43,71 +46,27 @@ to quickly create a Cobra application.`,
 			fmt.Fprintf(os.Stderr, "could not load database: %v\n", err)
 			os.Exit(1)
 		}
+		e := engine.New(engine.Options{
+			Parallelism: parallelism,
+			Timeout:     timeout,
+		})
+		jobs := e.Run(cmd.Context(), config)
 		w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
-		var line string
-		for kkk, r := range config.Resources {
-			implementedRequirements := 0
-			for kk, req := range r.Requirements {
-				totalImplementations := 0
-				attestedImplementations := 0
-				for k, i := range req.Implementations {
-					verifiedAttestations := 0
-					if utils.Contains(req.Requirement.RequiredImplementationClasses, i.Implementation.Class) {
-						totalImplementations = totalImplementations + 1
-					}
-					for _, a := range i.Attestation {
-						line = fmt.Sprintf("Resource:\t%v\nRequirement:\t'%v'\nImplementation:\t'%v'\nAttestation:\t'%v'\nResult:\t", r.Name, req.Requirement.Name, i.Implementation.Name, a.Attestation.Name)
-						_, err := w.Write([]byte(line))
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
-						}
-						attester, _ := attester.Init(a.Attestation.Type)
-						res, err := attester.Attest(a.Attestation)
-						if err != nil {
-							os.Exit(1)
-						}
-						a.Attested = res.Result == "PASS"
-						line = fmt.Sprintf("%v\n\n", res.Result)
-						_, err = w.Write([]byte(line))
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
-						}
-						if a.Attested {
-							verifiedAttestations = verifiedAttestations + 1
-						}
-					}
-					i.TotalAttestations = len(i.Attestation)
-					i.Attested = false
-					i.VerifiedAttestations = verifiedAttestations
-					if i.VerifiedAttestations == i.TotalAttestations {
-						i.Attested = true
-					}
-					if i.Attested && utils.Contains(req.Requirement.RequiredImplementationClasses, i.Implementation.Class) {
-						attestedImplementations = attestedImplementations + 1
-					}
-					req.Implementations[k] = i
-				}
-				req.AttestedImplementations = attestedImplementations
-				req.TotalImplementations = totalImplementations
-				if len(req.Requirement.RequiredImplementationClasses) <= req.AttestedImplementations {
-					req.Implemented = true
-					implementedRequirements = implementedRequirements + 1
-				}
-				r.Requirements[kk] = req
-			}
-			r.ImplementedRequirements = implementedRequirements
-			if len(r.Requirements) == implementedRequirements {
-				r.Implemented = true
+		for _, job := range jobs {
+			line := fmt.Sprintf("Resource:\t%v\nRequirement:\t'%v'\nImplementation:\t'%v'\nAttestation:\t'%v'\nResult:\t%v\n\n", job.Resource, job.Requirement, job.Implementation, job.Attestation, job.Result.Result)
+			_, err := w.Write([]byte(line))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 			}
-			config.Resources[kkk] = r
 		}
 		w.Flush()
+		applyCompliancePolicy(cmd, c, config)
 		err = db.Save(config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "could not save db after attestation: %v\n", err)
 			os.Exit(1)
 		}
-		err = results.Summary(config, "tsv")
+		err = results.Summary(os.Stdout, config, "tsv")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "could not generate summary: %v\n", err)
 			os.Exit(1)
@@ -117,6 +76,9 @@ to quickly create a Cobra application.`,
 
 func init() {
 	rootCmd.AddCommand(attestCmd)
+	attestCmd.Flags().IntVarP(&parallelism, "parallelism", "p", engine.DefaultParallelism, "number of attestations to run concurrently")
+	attestCmd.Flags().DurationVar(&timeout, "timeout", engine.DefaultTimeout, "maximum duration of a single attestation. 0 disables the timeout")
+	attestCmd.Flags().BoolVar(&requireCompliantDescendants, "require-compliant-descendants", false, "a resource is compliant only when all of its descendants are. Overrides 'requireCompliantDescendants' in the config file")
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/history"
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/storage"
+	"github.com/ContainerSolutions/argus/cli/pkg/storage/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var historyResource string
+var historyRequirement string
+var historySince time.Duration
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Inspect past attestation runs",
+	Long: `Inspect past attestation runs. Requires a storage driver that keeps history, such as 'sqlite'.
+
+- runs        - lists every saved run
+- compliance  - shows the compliance of every resource over time
+- stats       - shows pass rate, mean time to remediate and longest failing streak per requirement code
+- diff        - shows the attestations whose result flipped between two runs
+`,
+}
+
+var historyRunsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "List past runs",
+	Run: func(cmd *cobra.Command, args []string) {
+		db := loadHistory()
+		runs, err := db.Runs()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not list runs: %v\n", err)
+			os.Exit(1)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Run\tSaved At\tNew Results\n")
+		for _, run := range runs {
+			fmt.Fprintf(w, "%v\t%v\t%v\t\n", run.ID, run.SavedAt.Format(time.RFC3339), run.Results)
+		}
+		w.Flush()
+	},
+}
+
+var historyComplianceCmd = &cobra.Command{
+	Use:   "compliance",
+	Short: "Show the compliance of every resource over time",
+	Run: func(cmd *cobra.Command, args []string) {
+		db := loadHistory()
+		runs, err := db.Runs()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not list runs: %v\n", err)
+			os.Exit(1)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Run\tSaved At\tResource\tStatus\tTotal Requirements\tImplemented Requirements\n")
+		for _, run := range runs {
+			if historySince > 0 && run.SavedAt.Before(time.Now().Add(-historySince)) {
+				continue
+			}
+			config, err := db.LoadRun(run.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "could not load run %v: %v\n", run.ID, err)
+				os.Exit(1)
+			}
+			for _, rc := range history.Compliance(run, config) {
+				if historyResource != "" && rc.Resource != historyResource {
+					continue
+				}
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", rc.RunID, rc.SavedAt.Format(time.RFC3339), rc.Resource, rc.Implemented, rc.TotalRequirements, rc.ImplementedRequirements)
+			}
+		}
+		w.Flush()
+	},
+}
+
+var historyStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show pass rate, mean time to remediate and longest failing streak per requirement code",
+	Run: func(cmd *cobra.Command, args []string) {
+		db := loadHistory()
+		filter := models.HistoryFilter{
+			Resource:        historyResource,
+			RequirementCode: historyRequirement,
+		}
+		if historySince > 0 {
+			filter.Since = time.Now().Add(-historySince)
+		}
+		records, err := db.History(filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not load history: %v\n", err)
+			os.Exit(1)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Requirement\tResults\tPass Rate\tRemediations\tMTTR\tLongest Failing Streak\tOpen Failures\n")
+		for _, s := range history.Stats(records) {
+			mttr := "N/A"
+			if s.Remediations > 0 {
+				mttr = s.MTTR.Round(time.Second).String()
+			}
+			fmt.Fprintf(w, "%v\t%v\t%.1f%%\t%v\t%v\t%v\t%v\t\n", s.RequirementCode, s.Results, s.PassRate*100, s.Remediations, mttr, s.LongestFailingStreak, s.OpenFailures)
+		}
+		w.Flush()
+	},
+}
+
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff <run> <run>",
+	Short: "Show the attestations whose result flipped between two runs",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		db := loadHistory()
+		configs := []*models.Configuration{}
+		for _, arg := range args {
+			id, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "'%v' is not a valid run\n", arg)
+				os.Exit(1)
+			}
+			config, err := db.LoadRun(id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "could not load run %v: %v\n", id, err)
+				os.Exit(1)
+			}
+			configs = append(configs, config)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Resource\tRequirement\tImplementation\tAttestation\tRun %v\tRun %v\n", args[0], args[1])
+		for _, f := range history.Diff(configs[0], configs[1]) {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", f.Resource, f.Requirement, f.Implementation, f.Attestation, f.Before, f.After)
+		}
+		w.Flush()
+	},
+}
+
+func loadHistory() schema.HistoryDriver {
+	c := loadConfig()
+	db, err := storage.Init(c.Driver)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not initialize database: %v\n", err)
+		os.Exit(1)
+	}
+	err = db.Configure(c.DriverConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not configure database: %v\n", err)
+		os.Exit(1)
+	}
+	h, ok := db.(schema.HistoryDriver)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "storage driver '%v' does not keep history. use the 'sqlite' driver instead\n", c.Driver)
+		os.Exit(1)
+	}
+	return h
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyRunsCmd)
+	historyCmd.AddCommand(historyComplianceCmd)
+	historyCmd.AddCommand(historyStatsCmd)
+	historyCmd.AddCommand(historyDiffCmd)
+	historyCmd.PersistentFlags().StringVarP(&historyResource, "resource", "r", "", "only show the given resource")
+	historyCmd.PersistentFlags().DurationVar(&historySince, "since", 0, "only show runs newer than this duration, e.g. '720h'")
+	historyStatsCmd.Flags().StringVar(&historyRequirement, "requirement", "", "only show the given requirement code")
+}
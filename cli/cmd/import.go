@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/oscal"
+
+	"github.com/spf13/cobra"
+)
+
+var importProfile string
+var importOutput string
+var importOutFile string
+var importOptions oscal.ImportOptions
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <catalog>",
+	Short: "Imports an OSCAL catalog as Requirements or operator Controls",
+	Long: `Imports the controls of an OSCAL catalog, in JSON or YAML, as Requirements for the configuration files
+or as Control manifests for the operator. Control ids become codes, the catalog version the version
+and the title of the group of each control its category. Withdrawn controls are left out.
+
+With --profile, only the controls the profile selects are imported, and Control manifests come with
+a Framework grouping them. Output is ordered as the catalog, so that importing a newer catalog only
+changes what changed in it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		doc, err := oscal.Read(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not read catalog: %v\n", err)
+			os.Exit(1)
+		}
+		if doc.Catalog == nil {
+			fmt.Fprintf(os.Stderr, "'%v' is not an OSCAL catalog\n", args[0])
+			os.Exit(1)
+		}
+		var profile *oscal.Profile
+		if importProfile != "" {
+			p, err := oscal.Read(importProfile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "could not read profile: %v\n", err)
+				os.Exit(1)
+			}
+			if p.Profile == nil {
+				fmt.Fprintf(os.Stderr, "'%v' is not an OSCAL profile\n", importProfile)
+				os.Exit(1)
+			}
+			profile = p.Profile
+		}
+		if importOutput == "controls" && len(importOptions.ImplementationClasses) == 0 {
+			fmt.Fprintln(os.Stderr, "--implementation-class is required for controls, as the operator rejects Controls without requiredAssessmentClasses")
+			os.Exit(1)
+		}
+		entries, err := oscal.Select(doc.Catalog, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not select controls: %v\n", err)
+			os.Exit(1)
+		}
+		var w io.Writer = os.Stdout
+		if importOutFile != "" {
+			f, err := os.Create(importOutFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "could not create output file: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
+		switch importOutput {
+		case "requirements":
+			err = oscal.WriteRequirements(w, oscal.Requirements(doc.Catalog, entries, importOptions))
+		case "controls":
+			err = oscal.WriteManifests(w, oscal.Controls(doc.Catalog, profile, entries, importOptions))
+		default:
+			fmt.Fprintf(os.Stderr, "'%v' is not a valid output\n", importOutput)
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not write output: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringVar(&importProfile, "profile", "", "OSCAL profile selecting the controls to import")
+	importCmd.Flags().StringVarP(&importOutput, "output", "o", "requirements", "what to import the controls as. Possible values are 'requirements' or 'controls'")
+	importCmd.Flags().StringVar(&importOutFile, "out", "", "file to write to. Defaults to stdout")
+	importCmd.Flags().StringVar(&importOptions.Version, "version", "", "version of the imported controls. Defaults to the catalog version")
+	importCmd.Flags().StringVar(&importOptions.Class, "class", "", "class of the imported controls. Defaults to the class of each control")
+	importCmd.Flags().StringSliceVar(&importOptions.ResourceClasses, "resource-class", nil, "class of the resources the controls apply to. May be repeated")
+	importCmd.Flags().StringSliceVar(&importOptions.ImplementationClasses, "implementation-class", nil, "class of the implementations the controls require. May be repeated. Required for controls")
+}
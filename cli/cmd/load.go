@@ -39,7 +39,7 @@ Must be run before program will work.`,
 		}
 		_, err = resolver.Resolve(p)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Could not resolve configuration '%v': %v\n", p, err)
+			fmt.Fprintf(os.Stderr, "Could not resolve configuration: %v\n", err)
 			os.Exit(1)
 		}
 		err = db.Save(p)
@@ -5,8 +5,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/ContainerSolutions/argus/cli/pkg/engine"
 	"github.com/ContainerSolutions/argus/cli/pkg/results"
 	"github.com/ContainerSolutions/argus/cli/pkg/storage"
 
@@ -15,6 +17,8 @@ import (
 
 var output string
 var mode string
+var outFile string
+var requireCompliantDescendants bool
 
 // reportCmd represents the report command
 var reportCmd = &cobra.Command{
@@ -43,21 +47,34 @@ var reportCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "could not configure database: %v\n", err)
 			os.Exit(1)
 		}
+		// Saved results may carry another compliance policy, so they are rolled up from their attestations again.
+		engine.Rollup(config)
+		applyCompliancePolicy(cmd, c, config)
+		var w io.Writer = os.Stdout
+		if outFile != "" {
+			f, err := os.Create(outFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "could not create output file: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
 		switch mode {
 		case "summary":
-			err := results.Summary(config, output)
+			err := results.Summary(w, config, output)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "could not generate report: %v\n", err)
 				os.Exit(1)
 			}
 		case "detailed":
-			err := results.Detailed(config, output)
+			err := results.Detailed(w, config, output)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "could not generate report: %v\n", err)
 				os.Exit(1)
 			}
 		case "all":
-			err := results.All(config, output)
+			err := results.All(w, config, output)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "could not generate report: %v\n", err)
 				os.Exit(1)
@@ -72,7 +89,9 @@ var reportCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(reportCmd)
 	reportCmd.Flags().StringVarP(&mode, "mode", "m", "summary", "type of report. Possible values are 'summary' or 'detailed'")
-	reportCmd.Flags().StringVarP(&output, "output", "o", "tsv", "command output. possible values are 'tsv' or 'json'")
+	reportCmd.Flags().StringVarP(&output, "output", "o", "tsv", "command output. possible values are 'tsv', 'json', 'junit', 'sarif', 'oscal' or 'html'")
+	reportCmd.Flags().StringVar(&outFile, "out", "", "file to write the report to. Defaults to stdout")
+	reportCmd.Flags().BoolVar(&requireCompliantDescendants, "require-compliant-descendants", false, "a resource is compliant only when all of its descendants are. Overrides 'requireCompliantDescendants' in the config file")
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ContainerSolutions/argus/cli/pkg/engine"
 	"github.com/ContainerSolutions/argus/cli/pkg/models"
 
 	"github.com/spf13/cobra"
@@ -65,10 +66,22 @@ func loadConfig() *models.ConfigFile {
 		os.Exit(1)
 	}
 	c := models.ConfigFile{}
-	err := viper.Unmarshal(&c)
+	err := viper.UnmarshalExact(&c)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Could not unmarshal config file '%v': %v\n", cfgFile, err)
 		os.Exit(1)
 	}
 	return &c
 }
+
+// applyCompliancePolicy makes the resources of rolled up results compliant only when all of their
+// descendants are, if either the config file or the command line asks for it.
+func applyCompliancePolicy(cmd *cobra.Command, c *models.ConfigFile, config *models.Configuration) {
+	strict := c.RequireCompliantDescendants
+	if cmd.Flags().Changed("require-compliant-descendants") {
+		strict = requireCompliantDescendants
+	}
+	if strict {
+		engine.RequireCompliantDescendants(config)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/jsonschema"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaDir string
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema [kind]",
+	Short: "Prints the JSON Schema of a configuration kind",
+	Long: `Prints the JSON Schema of a configuration kind, so editors can validate and autocomplete configuration files.
+Without a kind, lists the available kinds. With --dir, writes the schema of every kind to that directory.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if schemaDir != "" {
+			err := os.MkdirAll(schemaDir, 0o755)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "could not create directory '%v': %v\n", schemaDir, err)
+				os.Exit(1)
+			}
+			for _, k := range jsonschema.Kinds {
+				path := filepath.Join(schemaDir, strings.ToLower(k.Name)+".json")
+				f, err := os.Create(path)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "could not create file '%v': %v\n", path, err)
+					os.Exit(1)
+				}
+				err = writeSchema(f, k)
+				f.Close()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "could not write schema '%v': %v\n", path, err)
+					os.Exit(1)
+				}
+			}
+			return
+		}
+		if len(args) == 0 {
+			for _, k := range jsonschema.Kinds {
+				fmt.Printf("%v\t%v\n", k.Name, k.Description)
+			}
+			return
+		}
+		k, ok := jsonschema.For(args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "'%v' is not a valid kind\n", args[0])
+			os.Exit(1)
+		}
+		err := writeSchema(os.Stdout, k)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not write schema: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func writeSchema(f *os.File, k jsonschema.Kind) error {
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonschema.Generate(k))
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.Flags().StringVar(&schemaDir, "dir", "", "write the schema of every kind to this directory")
+}
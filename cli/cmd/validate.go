@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/parser"
+	"github.com/ContainerSolutions/argus/cli/pkg/validator"
+
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Checks the configuration files for dangling references",
+	Long: `Parses the configuration files and reports every problem found with the file it comes from:
+requirementRef, resourceRef, parents and implementationRef entries that do not match anything,
+unknown attestation types and duplicate definitions.
+
+Exits with a non-zero code if any problem is found.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := loadConfig()
+		p, sources, err := parser.ParseWithSources(c)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not parse config file '%v': %v\n", cfgFile, err)
+			os.Exit(1)
+		}
+		problems := validator.Validate(p, sources)
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stderr, problem)
+		}
+		if len(problems) > 0 {
+			fmt.Fprintf(os.Stderr, "found %v problems\n", len(problems))
+			os.Exit(1)
+		}
+		fmt.Println("configuration is valid")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
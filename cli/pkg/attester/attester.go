@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	_ "github.com/ContainerSolutions/argus/cli/pkg/attester/command"
+	_ "github.com/ContainerSolutions/argus/cli/pkg/attester/http"
 	"github.com/ContainerSolutions/argus/cli/pkg/attester/schema"
 )
 
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -15,7 +16,7 @@ type AttestCommand struct {
 }
 
 type Command interface {
-	Command(name string, arg ...string)
+	Command(ctx context.Context, name string, arg ...string) Command
 	CombinedOutput() ([]byte, error)
 	String() string
 	ExitCode() int
@@ -24,8 +25,10 @@ type ExecCommand struct {
 	command *exec.Cmd
 }
 
-func (e *ExecCommand) Command(name string, arg ...string) {
-	e.command = exec.Command(name, arg...)
+func (e *ExecCommand) Command(ctx context.Context, name string, arg ...string) Command {
+	return &ExecCommand{
+		command: exec.CommandContext(ctx, name, arg...),
+	}
 }
 
 func (e *ExecCommand) CombinedOutput() ([]byte, error) {
@@ -45,19 +48,19 @@ func init() {
 	})
 }
 
-func (f *AttestCommand) Attest(a *models.Attestation) (*models.AttestationResult, error) {
-	f.cmd.Command(a.CommandRef.Command, a.CommandRef.Args...)
+func (f *AttestCommand) Attest(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+	cmd := f.cmd.Command(ctx, a.CommandRef.Command, a.CommandRef.Args...)
 	res := models.AttestationResult{}
-	out, err := f.cmd.CombinedOutput()
-	res.Command = f.cmd.String()
+	out, err := cmd.CombinedOutput()
+	res.Command = cmd.String()
 	res.Logs = fmt.Sprintf("$ %v:\n%v", res.Command, string(out))
 	res.RunAt = time.Now()
 	if err != nil {
 		res.Err = err.Error()
 	}
-	if f.cmd.ExitCode() != a.CommandRef.ExpectedExitCode {
+	if cmd.ExitCode() != a.CommandRef.ExpectedExitCode {
 		res.Result = "FAIL"
-		res.Reason = fmt.Sprintf("Code failed! Got %v But Expected %v\n", f.cmd.ExitCode(), a.CommandRef.ExpectedExitCode)
+		res.Reason = fmt.Sprintf("Code failed! Got %v But Expected %v\n", cmd.ExitCode(), a.CommandRef.ExpectedExitCode)
 		a.Result = res
 		return &res, nil
 	}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"github.com/ContainerSolutions/argus/cli/pkg/models"
 	"testing"
@@ -14,7 +15,8 @@ type FakeCommand struct {
 	ExitCodeFn       func() int
 }
 
-func (f *FakeCommand) Command(name string, arg ...string) {
+func (f *FakeCommand) Command(ctx context.Context, name string, arg ...string) Command {
+	return f
 }
 
 func (f *FakeCommand) CombinedOutput() ([]byte, error) {
@@ -158,7 +160,7 @@ func TestCommand(t *testing.T) {
 			f.CombinedOutputFn = tc.combinedOutputFn
 			f.StringFn = tc.stringFn
 			f.ExitCodeFn = tc.exitCodefn
-			res, err := a.Attest(tc.input)
+			res, err := a.Attest(context.Background(), tc.input)
 			if tc.expectedErr != "" {
 				assert.ErrorContains(t, err, tc.expectedErr)
 			}
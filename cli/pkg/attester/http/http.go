@@ -0,0 +1,195 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/attester/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+
+	"github.com/PaesslerAG/jsonpath"
+)
+
+const defaultTimeout = 30 * time.Second
+
+type AttestHTTP struct {
+}
+
+func init() {
+	schema.Register("http", &AttestHTTP{})
+}
+
+func (f *AttestHTTP) Attest(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+	ref := a.HTTPRef
+	client, err := newClient(ref)
+	if err != nil {
+		return nil, fmt.Errorf("could not configure http client for attestation '%v': %w", a.Name, err)
+	}
+	method := ref.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, ref.URL, strings.NewReader(ref.Body))
+	if err != nil {
+		return nil, fmt.Errorf("could not build request for attestation '%v': %w", a.Name, err)
+	}
+	for k, v := range ref.Headers {
+		req.Header.Set(k, v)
+	}
+	res := models.AttestationResult{}
+	res.Command = fmt.Sprintf("%v %v", method, ref.URL)
+	res.RunAt = time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		// No response tells nothing about compliance.
+		res.Err = err.Error()
+		res.Logs = fmt.Sprintf("$ %v:\n", res.Command)
+		res.Result = "UNKNOWN"
+		res.Reason = fmt.Sprintf("Request failed! %v\n", err)
+		a.Result = res
+		return &res, nil
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		res.Err = err.Error()
+	}
+	res.Logs = fmt.Sprintf("$ %v:\n%v", res.Command, dumpResponse(resp, body))
+	expectedStatusCode := ref.ExpectedStatusCode
+	if expectedStatusCode == 0 {
+		expectedStatusCode = http.StatusOK
+	}
+	if resp.StatusCode != expectedStatusCode {
+		res.Result = "FAIL"
+		res.Reason = fmt.Sprintf("Status Code failed! Got %v But Expected %v\n", resp.StatusCode, expectedStatusCode)
+		a.Result = res
+		return &res, nil
+	}
+	for _, name := range utils.SortedKeys(ref.ExpectedHeaders) {
+		expr, err := regexp.Compile(ref.ExpectedHeaders[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression for header '%v': %w", name, err)
+		}
+		if !expr.MatchString(resp.Header.Get(name)) {
+			res.Result = "FAIL"
+			res.Reason = fmt.Sprintf("Header Check failed! Wanted '%v' to match '%v'\nGot '%v'\n", name, ref.ExpectedHeaders[name], resp.Header.Get(name))
+			a.Result = res
+			return &res, nil
+		}
+	}
+	if ref.ExpectedBody != "" {
+		expr, err := regexp.Compile(ref.ExpectedBody)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression for body: %w", err)
+		}
+		if !expr.Match(body) {
+			res.Result = "FAIL"
+			res.Reason = fmt.Sprintf("Body Check failed! Wanted match for '%v'\nGot '%v'\n", ref.ExpectedBody, string(body))
+			a.Result = res
+			return &res, nil
+		}
+	}
+	if len(ref.ExpectedJSONPath) > 0 {
+		var doc interface{}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			res.Err = err.Error()
+			res.Result = "FAIL"
+			res.Reason = "JSONPath Check failed! Response body is not valid JSON\n"
+			a.Result = res
+			return &res, nil
+		}
+		for _, check := range ref.ExpectedJSONPath {
+			value, err := jsonpath.Get(check.Path, doc)
+			if err != nil {
+				res.Result = "FAIL"
+				res.Reason = fmt.Sprintf("JSONPath Check failed! Could not resolve '%v': %v\n", check.Path, err)
+				a.Result = res
+				return &res, nil
+			}
+			if check.Value != "" && stringify(value) != check.Value {
+				res.Result = "FAIL"
+				res.Reason = fmt.Sprintf("JSONPath Check failed! Wanted '%v' at '%v'\nGot '%v'\n", check.Value, check.Path, stringify(value))
+				a.Result = res
+				return &res, nil
+			}
+		}
+	}
+	res.Result = "PASS"
+	res.Reason = ""
+	a.Result = res
+	return &res, nil
+}
+
+func newClient(ref models.AttestationByHTTP) (*http.Client, error) {
+	timeout := defaultTimeout
+	if ref.Timeout != "" {
+		t, err := time.ParseDuration(ref.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout '%v': %w", ref.Timeout, err)
+		}
+		timeout = t
+	}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: ref.TLS.InsecureSkipVerify, //nolint:gosec
+		ServerName:         ref.TLS.ServerName,
+	}
+	if ref.TLS.CAFile != "" {
+		ca, err := os.ReadFile(ref.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA file %v: %w", ref.TLS.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA file %v", ref.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if ref.TLS.CertFile != "" || ref.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(ref.TLS.CertFile, ref.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, nil
+}
+
+func dumpResponse(resp *http.Response, body []byte) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%v %v\n", resp.Proto, resp.Status)
+	for _, name := range utils.SortedKeys(resp.Header) {
+		fmt.Fprintf(&b, "%v: %v\n", name, strings.Join(resp.Header[name], ", "))
+	}
+	fmt.Fprintf(&b, "\n%v", string(body))
+	return b.String()
+}
+
+func stringify(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64, bool:
+		return fmt.Sprint(t)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
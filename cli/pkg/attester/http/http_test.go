@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func newServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok","enforcementMode":"Default","replicas":3,"items":[{"name":"a"},{"name":"b"}]}`)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "plain text")
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestHTTP(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsSrv.Close()
+	closedSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedSrv.Close()
+	a := AttestHTTP{}
+	testCase := []struct {
+		name           string
+		input          models.AttestationByHTTP
+		expectedErr    string
+		expectedResult *models.AttestationResult
+	}{
+		{
+			name: "StatusCodeMatches",
+			input: models.AttestationByHTTP{
+				URL: srv.URL + "/json",
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/json",
+				Result:  "PASS",
+				Reason:  "",
+			},
+		},
+		{
+			name: "StatusCodeFail",
+			input: models.AttestationByHTTP{
+				URL: srv.URL + "/missing",
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/missing",
+				Result:  "FAIL",
+				Reason:  "Status Code failed! Got 404 But Expected 200\n",
+			},
+		},
+		{
+			name: "MethodHeadersAndBody",
+			input: models.AttestationByHTTP{
+				Method:             http.MethodPost,
+				URL:                srv.URL + "/echo",
+				Headers:            map[string]string{"X-Token": "secret"},
+				Body:               "{}",
+				ExpectedStatusCode: http.StatusCreated,
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "POST " + srv.URL + "/echo",
+				Result:  "PASS",
+			},
+		},
+		{
+			name: "HeaderFail",
+			input: models.AttestationByHTTP{
+				URL:             srv.URL + "/text",
+				ExpectedHeaders: map[string]string{"Content-Type": "^application/json"},
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/text",
+				Result:  "FAIL",
+				Reason:  "Header Check failed! Wanted 'Content-Type' to match '^application/json'\nGot 'text/plain; charset=utf-8'\n",
+			},
+		},
+		{
+			name: "BodyRegexp",
+			input: models.AttestationByHTTP{
+				URL:          srv.URL + "/json",
+				ExpectedBody: `"enforcementMode":\s*"Default"`,
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/json",
+				Result:  "PASS",
+			},
+		},
+		{
+			name: "BodyFail",
+			input: models.AttestationByHTTP{
+				URL:          srv.URL + "/text",
+				ExpectedBody: "^other",
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/text",
+				Result:  "FAIL",
+				Reason:  "Body Check failed! Wanted match for '^other'\nGot 'plain text'\n",
+			},
+		},
+		{
+			name: "JSONPath",
+			input: models.AttestationByHTTP{
+				URL: srv.URL + "/json",
+				ExpectedJSONPath: []models.JSONPathAssertion{
+					{Path: "$.status", Value: "ok"},
+					{Path: "$.replicas", Value: "3"},
+					{Path: "$.items[*].name", Value: `["a","b"]`},
+					{Path: "$.enforcementMode"},
+				},
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/json",
+				Result:  "PASS",
+			},
+		},
+		{
+			name: "JSONPathValueFail",
+			input: models.AttestationByHTTP{
+				URL: srv.URL + "/json",
+				ExpectedJSONPath: []models.JSONPathAssertion{
+					{Path: "$.replicas", Value: "5"},
+				},
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/json",
+				Result:  "FAIL",
+				Reason:  "JSONPath Check failed! Wanted '5' at '$.replicas'\nGot '3'\n",
+			},
+		},
+		{
+			name: "JSONPathNotJSON",
+			input: models.AttestationByHTTP{
+				URL: srv.URL + "/text",
+				ExpectedJSONPath: []models.JSONPathAssertion{
+					{Path: "$.status"},
+				},
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + srv.URL + "/text",
+				Result:  "FAIL",
+				Reason:  "JSONPath Check failed! Response body is not valid JSON\n",
+			},
+		},
+		{
+			name: "TLSUntrusted",
+			input: models.AttestationByHTTP{
+				URL: tlsSrv.URL,
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + tlsSrv.URL,
+				Result:  "UNKNOWN",
+			},
+		},
+		{
+			name: "Unreachable",
+			input: models.AttestationByHTTP{
+				URL: closedSrv.URL,
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + closedSrv.URL,
+				Result:  "UNKNOWN",
+			},
+		},
+		{
+			name: "TLSInsecureSkipVerify",
+			input: models.AttestationByHTTP{
+				URL: tlsSrv.URL,
+				TLS: models.HTTPTLSConfig{InsecureSkipVerify: true},
+			},
+			expectedResult: &models.AttestationResult{
+				Command: "GET " + tlsSrv.URL,
+				Result:  "PASS",
+			},
+		},
+		{
+			name: "InvalidTimeout",
+			input: models.AttestationByHTTP{
+				URL:     srv.URL,
+				Timeout: "soon",
+			},
+			expectedErr: "invalid timeout 'soon'",
+		},
+		{
+			name: "InvalidBodyExpression",
+			input: models.AttestationByHTTP{
+				URL:          srv.URL + "/text",
+				ExpectedBody: "(",
+			},
+			expectedErr: "invalid expression for body",
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			input := &models.Attestation{
+				Name:    "fake",
+				Type:    "http",
+				HTTPRef: tc.input,
+			}
+			res, err := a.Attest(context.Background(), input)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			if tc.expectedResult != nil {
+				assert.Equal(t, tc.expectedResult.Command, res.Command)
+				assert.Equal(t, tc.expectedResult.Result, res.Result)
+				if tc.expectedResult.Reason != "" {
+					assert.Equal(t, tc.expectedResult.Reason, res.Reason)
+				}
+				assert.Equal(t, res.Result, input.Result.Result)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package schema
 
-import "github.com/ContainerSolutions/argus/cli/pkg/models"
+import (
+	"context"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+)
 
 type AttestDriver interface {
-	Attest(c *models.Attestation) (*models.AttestationResult, error)
+	Attest(ctx context.Context, c *models.Attestation) (*models.AttestationResult, error)
 }
 
 var Registry = make(map[string]AttestDriver)
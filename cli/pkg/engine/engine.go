@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/attester"
+	"github.com/ContainerSolutions/argus/cli/pkg/attester/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/resolver"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+const (
+	DefaultParallelism = 4
+	DefaultTimeout     = 5 * time.Minute
+)
+
+type Options struct {
+	// Parallelism is the number of attestations run at the same time.
+	Parallelism int
+	// Timeout bounds a single attestation run. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// Job is a single Resource/Requirement/Implementation/Attestation run.
+type Job struct {
+	Resource       string
+	Requirement    string
+	Implementation string
+	Attestation    string
+	Result         models.AttestationResult
+
+	resource       int
+	requirementKey string
+	implKey        string
+	attKey         string
+	attestation    models.Attestation
+}
+
+type Engine struct {
+	opts Options
+	init func(name string) (schema.AttestDriver, error)
+}
+
+func New(opts Options) *Engine {
+	if opts.Parallelism < 1 {
+		opts.Parallelism = 1
+	}
+	return &Engine{
+		opts: opts,
+		init: attester.Init,
+	}
+}
+
+// Run attests every attestation in the configuration and rolls up the results.
+// Failing attesters never abort the run; they are recorded as UNKNOWN results.
+// Jobs are returned in a deterministic order regardless of completion order.
+func (e *Engine) Run(ctx context.Context, config *models.Configuration) []Job {
+	jobs := collect(config)
+	work := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < e.opts.Parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				jobs[idx].Result = e.attest(ctx, &jobs[idx].attestation)
+			}
+		}()
+	}
+	for idx := range jobs {
+		work <- idx
+	}
+	close(work)
+	wg.Wait()
+	for _, job := range jobs {
+		block := config.Resources[job.resource].Requirements[job.requirementKey].Implementations[job.implKey].Attestation[job.attKey]
+		block.Attestation.Result = job.Result
+		block.Attested = job.Result.Result == "PASS"
+		block.RunAt = job.Result.RunAt.String()
+		block.Logs = job.Result.Logs
+		config.Resources[job.resource].Requirements[job.requirementKey].Implementations[job.implKey].Attestation[job.attKey] = block
+	}
+	Rollup(config)
+	return jobs
+}
+
+func (e *Engine) attest(ctx context.Context, a *models.Attestation) models.AttestationResult {
+	if e.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.opts.Timeout)
+		defer cancel()
+	}
+	driver, err := e.init(a.Type)
+	if err != nil {
+		return unknown(fmt.Sprintf("could not initialize attester '%v'", a.Type), err)
+	}
+	res, err := driver.Attest(ctx, a)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		r := unknown(fmt.Sprintf("attestation timed out after %v", e.opts.Timeout), ctx.Err())
+		if res != nil {
+			r.Command = res.Command
+			r.Logs = res.Logs
+		}
+		return r
+	}
+	if err != nil {
+		return unknown("attester returned an error", err)
+	}
+	return *res
+}
+
+func unknown(reason string, err error) models.AttestationResult {
+	return models.AttestationResult{
+		Result: "UNKNOWN",
+		Reason: reason,
+		Err:    err.Error(),
+		RunAt:  time.Now(),
+	}
+}
+
+// Rollup computes the Implementation, Requirement and Resource counters from attestation results,
+// along with the child and descendant counters of every resource.
+func Rollup(config *models.Configuration) {
+	for kkk, r := range config.Resources {
+		implementedRequirements := 0
+		for kk, req := range r.Requirements {
+			totalImplementations := 0
+			attestedImplementations := 0
+			for k, i := range req.Implementations {
+				verifiedAttestations := 0
+				if utils.Contains(req.Requirement.RequiredImplementationClasses, i.Implementation.Class) {
+					totalImplementations = totalImplementations + 1
+				}
+				for _, a := range i.Attestation {
+					if a.Attested {
+						verifiedAttestations = verifiedAttestations + 1
+					}
+				}
+				i.TotalAttestations = len(i.Attestation)
+				i.VerifiedAttestations = verifiedAttestations
+				i.Attested = i.VerifiedAttestations == i.TotalAttestations
+				if i.Attested && utils.Contains(req.Requirement.RequiredImplementationClasses, i.Implementation.Class) {
+					attestedImplementations = attestedImplementations + 1
+				}
+				req.Implementations[k] = i
+			}
+			req.AttestedImplementations = attestedImplementations
+			req.TotalImplementations = totalImplementations
+			req.Implemented = len(req.Requirement.RequiredImplementationClasses) <= req.AttestedImplementations
+			if req.Implemented {
+				implementedRequirements = implementedRequirements + 1
+			}
+			r.Requirements[kk] = req
+		}
+		r.ImplementedRequirements = implementedRequirements
+		r.Implemented = len(r.Requirements) == implementedRequirements
+		r.Compliant = r.Implemented
+		config.Resources[kkk] = r
+	}
+	rollupHierarchy(config)
+}
+
+// RequireCompliantDescendants marks a resource as compliant only if it and all of its
+// descendants implement their requirements. It must run after Rollup.
+func RequireCompliantDescendants(config *models.Configuration) {
+	for k, r := range config.Resources {
+		r.Compliant = r.Implemented && r.CompliantDescendants == r.Descendants
+		config.Resources[k] = r
+	}
+	rollupHierarchy(config)
+}
+
+// rollupHierarchy counts the children and descendants of every resource. Children are
+// counted as compliant by their Compliant status, descendants by their Implemented status.
+// Configurations with cycles in their parents are left untouched.
+func rollupHierarchy(config *models.Configuration) {
+	h, err := resolver.NewHierarchy(config.Resources)
+	if err != nil {
+		return
+	}
+	byName := map[string]models.Resource{}
+	for _, r := range config.Resources {
+		byName[r.Name] = r
+	}
+	for k, r := range config.Resources {
+		children := h.Children(r.Name)
+		descendants := h.Descendants(r.Name)
+		r.Children = len(children)
+		r.CompliantChildren = 0
+		for _, c := range children {
+			if byName[c].Compliant {
+				r.CompliantChildren = r.CompliantChildren + 1
+			}
+		}
+		r.Descendants = len(descendants)
+		r.CompliantDescendants = 0
+		for _, d := range descendants {
+			if byName[d].Implemented {
+				r.CompliantDescendants = r.CompliantDescendants + 1
+			}
+		}
+		config.Resources[k] = r
+	}
+}
+
+func collect(config *models.Configuration) []Job {
+	jobs := []Job{}
+	for idx, r := range config.Resources {
+		for _, reqKey := range utils.SortedKeys(r.Requirements) {
+			req := r.Requirements[reqKey]
+			for _, impKey := range utils.SortedKeys(req.Implementations) {
+				imp := req.Implementations[impKey]
+				for _, attKey := range utils.SortedKeys(imp.Attestation) {
+					att := imp.Attestation[attKey]
+					jobs = append(jobs, Job{
+						Resource:       r.Name,
+						Requirement:    req.Requirement.Name,
+						Implementation: imp.Implementation.Name,
+						Attestation:    att.Attestation.Name,
+						resource:       idx,
+						requirementKey: reqKey,
+						implKey:        impKey,
+						attKey:         attKey,
+						// Attesters write into the attestation they get; hand them a copy
+						// so that shared attestations are never written concurrently.
+						attestation: *att.Attestation,
+					})
+				}
+			}
+		}
+	}
+	return jobs
+}
@@ -0,0 +1,258 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/attester/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+type FakeDriver struct {
+	AttestFn func(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error)
+}
+
+func (f *FakeDriver) Attest(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+	return f.AttestFn(ctx, a)
+}
+
+func WithResult(result string) *FakeDriver {
+	return &FakeDriver{
+		AttestFn: func(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+			return &models.AttestationResult{Result: result, RunAt: time.Now()}, nil
+		},
+	}
+}
+
+func WithError(err error) *FakeDriver {
+	return &FakeDriver{
+		AttestFn: func(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+			return nil, err
+		},
+	}
+}
+
+func WithHang() *FakeDriver {
+	return &FakeDriver{
+		AttestFn: func(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+			<-ctx.Done()
+			return &models.AttestationResult{Result: "FAIL", Command: "hang"}, nil
+		},
+	}
+}
+
+func newEngine(opts Options, drivers map[string]schema.AttestDriver) *Engine {
+	e := New(opts)
+	e.init = func(name string) (schema.AttestDriver, error) {
+		d, ok := drivers[name]
+		if !ok {
+			return nil, fmt.Errorf("driver not found")
+		}
+		return d, nil
+	}
+	return e
+}
+
+// makeConfig builds a single resource with one requirement and implementation,
+// holding one attestation per given type.
+func makeConfig(types ...string) *models.Configuration {
+	req := &models.Requirement{Name: "req", RequiredImplementationClasses: []string{"Preventative"}}
+	imp := &models.Implementation{Name: "imp", Class: "Preventative"}
+	attestations := map[string]models.AttestationBlock{}
+	for i, t := range types {
+		name := fmt.Sprintf("att-%02d", i)
+		attestations[name] = models.AttestationBlock{
+			Attestation: &models.Attestation{Name: name, Type: t},
+		}
+	}
+	return &models.Configuration{
+		Resources: []models.Resource{
+			{
+				Name: "res",
+				Requirements: map[string]models.RequirementBlock{
+					"req": {
+						Requirement: req,
+						Implementations: map[string]models.ImplementationBlock{
+							"imp": {
+								Implementation: imp,
+								Attestation:    attestations,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	drivers := map[string]schema.AttestDriver{
+		"pass":  WithResult("PASS"),
+		"fail":  WithResult("FAIL"),
+		"error": WithError(errors.New("boom")),
+		"hang":  WithHang(),
+	}
+	testCase := []struct {
+		name                string
+		types               []string
+		expectedResults     []string
+		expectedReasons     []string
+		expectedImplemented bool
+		expectedVerified    int
+	}{
+		{
+			name:                "AllPass",
+			types:               []string{"pass", "pass"},
+			expectedResults:     []string{"PASS", "PASS"},
+			expectedImplemented: true,
+			expectedVerified:    2,
+		},
+		{
+			name:                "OneFails",
+			types:               []string{"pass", "fail"},
+			expectedResults:     []string{"PASS", "FAIL"},
+			expectedImplemented: false,
+			expectedVerified:    1,
+		},
+		{
+			name:                "ErrorIsUnknown",
+			types:               []string{"error", "pass"},
+			expectedResults:     []string{"UNKNOWN", "PASS"},
+			expectedReasons:     []string{"attester returned an error", ""},
+			expectedImplemented: false,
+			expectedVerified:    1,
+		},
+		{
+			name:                "MissingDriverIsUnknown",
+			types:               []string{"nope"},
+			expectedResults:     []string{"UNKNOWN"},
+			expectedReasons:     []string{"could not initialize attester 'nope'"},
+			expectedImplemented: false,
+			expectedVerified:    0,
+		},
+		{
+			name:                "TimeoutIsUnknown",
+			types:               []string{"hang", "pass"},
+			expectedResults:     []string{"UNKNOWN", "PASS"},
+			expectedReasons:     []string{"attestation timed out after 50ms", ""},
+			expectedImplemented: false,
+			expectedVerified:    1,
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			config := makeConfig(tc.types...)
+			e := newEngine(Options{Parallelism: 2, Timeout: 50 * time.Millisecond}, drivers)
+			jobs := e.Run(context.Background(), config)
+			assert.Equal(t, len(tc.expectedResults), len(jobs))
+			for i, job := range jobs {
+				assert.Equal(t, fmt.Sprintf("att-%02d", i), job.Attestation)
+				assert.Equal(t, tc.expectedResults[i], job.Result.Result)
+				if tc.expectedReasons != nil {
+					assert.Equal(t, tc.expectedReasons[i], job.Result.Reason)
+				}
+			}
+			r := config.Resources[0]
+			imp := r.Requirements["req"].Implementations["imp"]
+			assert.Equal(t, len(tc.types), imp.TotalAttestations)
+			assert.Equal(t, tc.expectedVerified, imp.VerifiedAttestations)
+			assert.Equal(t, tc.expectedImplemented, r.Requirements["req"].Implemented)
+			assert.Equal(t, tc.expectedImplemented, r.Implemented)
+		})
+	}
+}
+
+func TestRunParallelism(t *testing.T) {
+	var running, peak int32
+	drivers := map[string]schema.AttestDriver{
+		"slow": &FakeDriver{
+			AttestFn: func(ctx context.Context, a *models.Attestation) (*models.AttestationResult, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return &models.AttestationResult{Result: "PASS"}, nil
+			},
+		},
+	}
+	config := makeConfig("slow", "slow", "slow", "slow", "slow", "slow")
+	e := newEngine(Options{Parallelism: 3}, drivers)
+	jobs := e.Run(context.Background(), config)
+	assert.Equal(t, 6, len(jobs))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&peak))
+	assert.Assert(t, config.Resources[0].Implemented)
+}
+
+func TestRollupHierarchy(t *testing.T) {
+	req := &models.Requirement{Name: "req", RequiredImplementationClasses: []string{"Preventative"}}
+	imp := &models.Implementation{Name: "imp", Class: "Preventative"}
+	resource := func(name string, attested bool, parents ...string) models.Resource {
+		return models.Resource{
+			Name:    name,
+			Parents: parents,
+			Requirements: map[string]models.RequirementBlock{
+				"req": {
+					Requirement: req,
+					Implementations: map[string]models.ImplementationBlock{
+						"imp": {
+							Implementation: imp,
+							Attestation: map[string]models.AttestationBlock{
+								"att": {Attestation: &models.Attestation{Name: "att"}, Attested: attested},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	// cloud -> cluster -> (vm-ok, vm-ko), cloud -> db
+	config := &models.Configuration{
+		Resources: []models.Resource{
+			resource("cloud", true),
+			resource("cluster", true, "cloud"),
+			resource("vm-ok", true, "cluster"),
+			resource("vm-ko", false, "cluster"),
+			resource("db", true, "cloud"),
+		},
+	}
+	type counts struct {
+		Compliant                                                      bool
+		Children, CompliantChildren, Descendants, CompliantDescendants int
+	}
+	get := func() map[string]counts {
+		res := map[string]counts{}
+		for _, r := range config.Resources {
+			res[r.Name] = counts{r.Compliant, r.Children, r.CompliantChildren, r.Descendants, r.CompliantDescendants}
+		}
+		return res
+	}
+
+	Rollup(config)
+	assert.DeepEqual(t, map[string]counts{
+		"cloud":   {true, 2, 2, 4, 3},
+		"cluster": {true, 2, 1, 2, 1},
+		"vm-ok":   {true, 0, 0, 0, 0},
+		"vm-ko":   {false, 0, 0, 0, 0},
+		"db":      {true, 0, 0, 0, 0},
+	}, get())
+
+	RequireCompliantDescendants(config)
+	assert.DeepEqual(t, map[string]counts{
+		"cloud":   {false, 2, 1, 4, 3},
+		"cluster": {false, 2, 1, 2, 1},
+		"vm-ok":   {true, 0, 0, 0, 0},
+		"vm-ko":   {false, 0, 0, 0, 0},
+		"db":      {true, 0, 0, 0, 0},
+	}, get())
+}
@@ -0,0 +1,195 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+// RequirementStats summarizes the attestation history of a single Requirement code.
+type RequirementStats struct {
+	RequirementCode string
+	Results         int
+	Passed          int
+	// PassRate is the share of results that passed, between 0 and 1.
+	PassRate float64
+	// Remediations is the number of failures that were followed by a passing result.
+	Remediations int
+	// MTTR is the mean time between the first failing result and the next passing one.
+	MTTR time.Duration
+	// LongestFailingStreak is the largest number of consecutive non passing results of any attestation.
+	LongestFailingStreak int
+	// OpenFailures is the number of attestations whose latest result is not passing.
+	OpenFailures int
+}
+
+// ResourceCompliance is the compliance of a Resource as saved in a given run.
+type ResourceCompliance struct {
+	RunID                   int64
+	SavedAt                 time.Time
+	Resource                string
+	Implemented             bool
+	ImplementedRequirements int
+	TotalRequirements       int
+}
+
+// Flip is an attestation whose result differs between two runs.
+type Flip struct {
+	Resource       string
+	Requirement    string
+	Implementation string
+	Attestation    string
+	Before         string
+	After          string
+}
+
+type attestationKey struct {
+	resource       string
+	requirement    string
+	implementation string
+	attestation    string
+}
+
+// Stats computes per Requirement code statistics out of attestation records.
+// Any result other than PASS counts as failing.
+func Stats(records []models.AttestationRecord) []RequirementStats {
+	sorted := make([]models.AttestationRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Result.RunAt.Before(sorted[j].Result.RunAt)
+	})
+	series := map[string]map[attestationKey][]models.AttestationRecord{}
+	for _, rec := range sorted {
+		if _, ok := series[rec.RequirementCode]; !ok {
+			series[rec.RequirementCode] = map[attestationKey][]models.AttestationRecord{}
+		}
+		key := attestationKey{rec.Resource, rec.Requirement, rec.Implementation, rec.Attestation}
+		series[rec.RequirementCode][key] = append(series[rec.RequirementCode][key], rec)
+	}
+	stats := []RequirementStats{}
+	for _, code := range utils.SortedKeys(series) {
+		s := RequirementStats{RequirementCode: code}
+		var remediation time.Duration
+		for _, recs := range series[code] {
+			streak := 0
+			var failingSince time.Time
+			for _, rec := range recs {
+				s.Results = s.Results + 1
+				if rec.Result.Result == "PASS" {
+					s.Passed = s.Passed + 1
+					if streak > 0 {
+						s.Remediations = s.Remediations + 1
+						remediation = remediation + rec.Result.RunAt.Sub(failingSince)
+					}
+					streak = 0
+					continue
+				}
+				if streak == 0 {
+					failingSince = rec.Result.RunAt
+				}
+				streak = streak + 1
+				if streak > s.LongestFailingStreak {
+					s.LongestFailingStreak = streak
+				}
+			}
+			if streak > 0 {
+				s.OpenFailures = s.OpenFailures + 1
+			}
+		}
+		if s.Results > 0 {
+			s.PassRate = float64(s.Passed) / float64(s.Results)
+		}
+		if s.Remediations > 0 {
+			s.MTTR = remediation / time.Duration(s.Remediations)
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// Compliance lists the compliance of every Resource in the configuration saved by the given run.
+func Compliance(run models.Run, c *models.Configuration) []ResourceCompliance {
+	compliance := []ResourceCompliance{}
+	for _, r := range c.Resources {
+		compliance = append(compliance, ResourceCompliance{
+			RunID:                   run.ID,
+			SavedAt:                 run.SavedAt,
+			Resource:                r.Name,
+			Implemented:             r.Implemented,
+			ImplementedRequirements: r.ImplementedRequirements,
+			TotalRequirements:       len(r.Requirements),
+		})
+	}
+	return compliance
+}
+
+// Diff lists every attestation whose result differs between two configurations.
+// Attestations missing from one of them are reported with an "N/A" result.
+func Diff(before, after *models.Configuration) []Flip {
+	b := results(before)
+	a := results(after)
+	keys := map[attestationKey]bool{}
+	for k := range b {
+		keys[k] = true
+	}
+	for k := range a {
+		keys[k] = true
+	}
+	flips := []Flip{}
+	for k := range keys {
+		br, ok := b[k]
+		if !ok {
+			br = "N/A"
+		}
+		ar, ok := a[k]
+		if !ok {
+			ar = "N/A"
+		}
+		if br == ar {
+			continue
+		}
+		flips = append(flips, Flip{
+			Resource:       k.resource,
+			Requirement:    k.requirement,
+			Implementation: k.implementation,
+			Attestation:    k.attestation,
+			Before:         br,
+			After:          ar,
+		})
+	}
+	sort.Slice(flips, func(i, j int) bool {
+		x, y := flips[i], flips[j]
+		if x.Resource != y.Resource {
+			return x.Resource < y.Resource
+		}
+		if x.Requirement != y.Requirement {
+			return x.Requirement < y.Requirement
+		}
+		if x.Implementation != y.Implementation {
+			return x.Implementation < y.Implementation
+		}
+		return x.Attestation < y.Attestation
+	})
+	return flips
+}
+
+func results(c *models.Configuration) map[attestationKey]string {
+	res := map[attestationKey]string{}
+	for _, r := range c.Resources {
+		for _, req := range r.Requirements {
+			for _, imp := range req.Implementations {
+				for _, att := range imp.Attestation {
+					key := attestationKey{r.Name, req.Requirement.Name, imp.Implementation.Name, att.Attestation.Name}
+					result := att.Attestation.Result.Result
+					if result == "" {
+						result = "NOT RUN"
+					}
+					res[key] = result
+				}
+			}
+		}
+	}
+	return res
+}
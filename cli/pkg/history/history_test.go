@@ -0,0 +1,131 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+var start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func record(code, attestation, result string, hours int) models.AttestationRecord {
+	return models.AttestationRecord{
+		Resource:        "vm",
+		Requirement:     code,
+		RequirementCode: code,
+		Implementation:  "imp",
+		Attestation:     attestation,
+		Result:          models.AttestationResult{Result: result, RunAt: start.Add(time.Duration(hours) * time.Hour)},
+	}
+}
+
+func TestStats(t *testing.T) {
+	testCase := []struct {
+		name     string
+		records  []models.AttestationRecord
+		expected []RequirementStats
+	}{
+		{
+			name:     "Empty",
+			records:  []models.AttestationRecord{},
+			expected: []RequirementStats{},
+		},
+		{
+			name: "AlwaysPass",
+			records: []models.AttestationRecord{
+				record("REQ-01", "a", "PASS", 0),
+				record("REQ-01", "a", "PASS", 1),
+			},
+			expected: []RequirementStats{
+				{RequirementCode: "REQ-01", Results: 2, Passed: 2, PassRate: 1},
+			},
+		},
+		{
+			name: "Remediated",
+			records: []models.AttestationRecord{
+				record("REQ-01", "a", "PASS", 0),
+				record("REQ-01", "a", "FAIL", 1),
+				record("REQ-01", "a", "UNKNOWN", 2),
+				record("REQ-01", "a", "FAIL", 3),
+				record("REQ-01", "a", "PASS", 5),
+				record("REQ-01", "a", "FAIL", 6),
+				record("REQ-01", "a", "PASS", 8),
+			},
+			expected: []RequirementStats{
+				{RequirementCode: "REQ-01", Results: 7, Passed: 3, PassRate: 3.0 / 7.0, Remediations: 2, MTTR: 3 * time.Hour, LongestFailingStreak: 3},
+			},
+		},
+		{
+			name: "UnorderedAndSplitPerAttestation",
+			records: []models.AttestationRecord{
+				record("REQ-02", "b", "FAIL", 2),
+				record("REQ-01", "a", "PASS", 1),
+				record("REQ-02", "a", "PASS", 4),
+				record("REQ-02", "a", "FAIL", 0),
+				record("REQ-02", "b", "FAIL", 3),
+			},
+			expected: []RequirementStats{
+				{RequirementCode: "REQ-01", Results: 1, Passed: 1, PassRate: 1},
+				{RequirementCode: "REQ-02", Results: 4, Passed: 1, PassRate: 0.25, Remediations: 1, MTTR: 4 * time.Hour, LongestFailingStreak: 2, OpenFailures: 1},
+			},
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.DeepEqual(t, tc.expected, Stats(tc.records))
+		})
+	}
+}
+
+func makeConfig(results map[string]string) *models.Configuration {
+	req := &models.Requirement{Name: "req"}
+	imp := &models.Implementation{Name: "imp"}
+	attestations := map[string]models.AttestationBlock{}
+	for name, result := range results {
+		attestations[name] = models.AttestationBlock{
+			Attestation: &models.Attestation{Name: name, Result: models.AttestationResult{Result: result}},
+		}
+	}
+	return &models.Configuration{
+		Resources: []models.Resource{
+			{
+				Name:                    "vm",
+				Implemented:             true,
+				ImplementedRequirements: 1,
+				Requirements: map[string]models.RequirementBlock{
+					"req": {
+						Requirement: req,
+						Implementations: map[string]models.ImplementationBlock{
+							"imp": {Implementation: imp, Attestation: attestations},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := makeConfig(map[string]string{"same": "PASS", "fixed": "FAIL", "broken": "PASS", "new": "", "removed": "PASS"})
+	after := makeConfig(map[string]string{"same": "PASS", "fixed": "PASS", "broken": "UNKNOWN", "new": "FAIL", "added": "PASS"})
+	expected := []Flip{
+		{Resource: "vm", Requirement: "req", Implementation: "imp", Attestation: "added", Before: "N/A", After: "PASS"},
+		{Resource: "vm", Requirement: "req", Implementation: "imp", Attestation: "broken", Before: "PASS", After: "UNKNOWN"},
+		{Resource: "vm", Requirement: "req", Implementation: "imp", Attestation: "fixed", Before: "FAIL", After: "PASS"},
+		{Resource: "vm", Requirement: "req", Implementation: "imp", Attestation: "new", Before: "NOT RUN", After: "FAIL"},
+		{Resource: "vm", Requirement: "req", Implementation: "imp", Attestation: "removed", Before: "PASS", After: "N/A"},
+	}
+	assert.DeepEqual(t, expected, Diff(before, after))
+	assert.DeepEqual(t, []Flip{}, Diff(before, before))
+}
+
+func TestCompliance(t *testing.T) {
+	run := models.Run{ID: 3, SavedAt: start}
+	expected := []ResourceCompliance{
+		{RunID: 3, SavedAt: start, Resource: "vm", Implemented: true, ImplementedRequirements: 1, TotalRequirements: 1},
+	}
+	assert.DeepEqual(t, expected, Compliance(run, makeConfig(map[string]string{})))
+}
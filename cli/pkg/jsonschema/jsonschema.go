@@ -0,0 +1,232 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema needed to describe the configuration kinds.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Const                string             `json:"const,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// Kind is a configuration object that can be written by users.
+type Kind struct {
+	Name        string
+	Description string
+	Value       interface{}
+	// Document kinds are tagged with a `kind` field in configuration files.
+	Document bool
+}
+
+// Kinds lists every configuration kind, in a stable order.
+var Kinds = []Kind{
+	{Name: models.KindResource, Description: "A Resource that requirements apply to, based on its classes.", Value: models.Resource{}, Document: true},
+	{Name: models.KindRequirement, Description: "A versioned Requirement applicable to resource classes.", Value: models.Requirement{}, Document: true},
+	{Name: models.KindImplementation, Description: "An Implementation of a Requirement on one or more resources.", Value: models.Implementation{}, Document: true},
+	{Name: models.KindAttestation, Description: "An Attestation verifying an Implementation.", Value: models.Attestation{}, Document: true},
+	{Name: "ConfigFile", Description: "The argus CLI configuration file.", Value: models.ConfigFile{}},
+	{Name: "Document", Description: "Any configuration document, discriminated by its kind."},
+}
+
+// For returns the kind with the given name, case insensitive.
+func For(name string) (Kind, bool) {
+	for _, k := range Kinds {
+		if strings.EqualFold(k.Name, name) {
+			return k, true
+		}
+	}
+	return Kind{}, false
+}
+
+// Generate builds the JSON Schema of a kind.
+// A kind without a value is the union of every document kind.
+func Generate(k Kind) *Schema {
+	var s *Schema
+	if k.Value == nil {
+		s = &Schema{Defs: map[string]*Schema{}}
+		for _, d := range Kinds {
+			if !d.Document {
+				continue
+			}
+			def := generate(d)
+			def.Required = append([]string{"kind"}, def.Required...)
+			s.Defs[d.Name] = def
+			s.OneOf = append(s.OneOf, &Schema{Ref: "#/$defs/" + d.Name})
+		}
+	} else {
+		s = generate(k)
+	}
+	s.Schema = draft
+	s.ID = fmt.Sprintf("https://github.com/ContainerSolutions/argus/cli/schemas/%v.json", strings.ToLower(k.Name))
+	s.Title = k.Name
+	s.Description = k.Description
+	return s
+}
+
+func generate(k Kind) *Schema {
+	s := schemaFor(reflect.TypeOf(k.Value))
+	if k.Document {
+		s.Properties["kind"] = &Schema{Type: "string", Const: k.Name}
+	}
+	return s
+}
+
+// field is a struct field as seen in configuration files.
+type field struct {
+	name     string
+	index    int
+	required bool
+}
+
+// fields lists the fields of a struct type that users may set, keyed by their json name.
+// Fields tagged with `jsonschema:"-"` hold computed state and are left out.
+func fields(t reflect.Type) map[string]field {
+	res := map[string]field{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		opts := strings.Split(f.Tag.Get("jsonschema"), ",")
+		if opts[0] == "-" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		res[name] = field{name: name, index: i, required: utils.Contains(opts, "required")}
+	}
+	return res
+}
+
+func schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+		for name, f := range fields(t) {
+			s.Properties[name] = schemaFor(t.Field(f.index).Type)
+			if f.required {
+				s.Required = append(s.Required, name)
+			}
+		}
+		sort.Strings(s.Required)
+		return s
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaFor(t.Elem())}
+	case reflect.Map:
+		s := &Schema{Type: "object"}
+		if t.Elem().Kind() != reflect.Interface {
+			s.AdditionalProperties = schemaFor(t.Elem())
+		}
+		return s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+// Check verifies that the JSON document only holds fields known to v, matching their
+// names exactly, and that every required field is set.
+func Check(data []byte, v interface{}) error {
+	var doc interface{}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return err
+	}
+	return check(doc, reflect.TypeOf(v), "")
+}
+
+func check(doc interface{}, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		known := fields(t)
+		for _, key := range utils.SortedKeys(obj) {
+			f, ok := known[key]
+			if !ok {
+				return fmt.Errorf("unknown field %q", join(path, key))
+			}
+			err := check(obj[key], t.Field(f.index).Type, join(path, key))
+			if err != nil {
+				return err
+			}
+		}
+		for _, name := range utils.SortedKeys(known) {
+			if _, ok := obj[name]; known[name].required && !ok {
+				return fmt.Errorf("missing required field %q", join(path, name))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := doc.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range items {
+			err := check(item, t.Elem(), fmt.Sprintf("%v[%v]", path, i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range utils.SortedKeys(obj) {
+			err := check(obj[key], t.Elem(), join(path, key))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
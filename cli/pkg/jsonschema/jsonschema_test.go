@@ -0,0 +1,108 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCheck(t *testing.T) {
+	testCase := []struct {
+		name          string
+		doc           string
+		value         interface{}
+		expectedError string
+	}{
+		{
+			name:  "Valid",
+			doc:   `{"name": "imp", "requirementRef": {"code": "REQ-01", "version": "1.0.0"}, "resourceRef": ["vm"]}`,
+			value: models.Implementation{},
+		},
+		{
+			name:          "UnknownField",
+			doc:           `{"name": "vm", "parent": ["cloud"]}`,
+			value:         models.Resource{},
+			expectedError: `unknown field "parent"`,
+		},
+		{
+			name:          "CaseMismatch",
+			doc:           `{"name": "imp", "requirementRef": {"Code": "REQ-01", "version": "1.0.0"}, "resourceRef": ["vm"]}`,
+			value:         models.Implementation{},
+			expectedError: `unknown field "requirementRef.Code"`,
+		},
+		{
+			name:          "StateFieldsAreNotConfiguration",
+			doc:           `{"name": "vm", "Implemented": true}`,
+			value:         models.Resource{},
+			expectedError: `unknown field "Implemented"`,
+		},
+		{
+			name:          "NestedInSlice",
+			doc:           `{"name": "a", "type": "http", "implementationRef": "imp", "httpRef": {"url": "http://x", "expectedJSONPath": [{"path": "$.a", "val": "b"}]}}`,
+			value:         models.Attestation{},
+			expectedError: `unknown field "httpRef.expectedJSONPath[0].val"`,
+		},
+		{
+			name:          "MissingRequired",
+			doc:           `{"name": "a", "type": "command"}`,
+			value:         models.Attestation{},
+			expectedError: `missing required field "implementationRef"`,
+		},
+		{
+			name:  "FreeFormMap",
+			doc:   `{"driver": "file", "driverConfig": {"file": "x", "anything": {"goes": 1}}}`,
+			value: models.ConfigFile{},
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Check([]byte(tc.doc), tc.value)
+			if tc.expectedError == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Error(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	k, ok := For("resource")
+	assert.Assert(t, ok)
+	s := Generate(k)
+	assert.Equal(t, "Resource", s.Title)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.DeepEqual(t, []string{"name"}, s.Required)
+	assert.DeepEqual(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, s.Properties["parents"])
+	assert.DeepEqual(t, &Schema{Type: "string", Const: "Resource"}, s.Properties["kind"])
+	_, ok = s.Properties["Requirements"]
+	assert.Assert(t, !ok)
+
+	k, ok = For("document")
+	assert.Assert(t, ok)
+	s = Generate(k)
+	assert.Equal(t, 4, len(s.OneOf))
+	assert.Equal(t, "#/$defs/Resource", s.OneOf[0].Ref)
+	assert.DeepEqual(t, []string{"kind", "name"}, s.Defs["Resource"].Required)
+}
+
+// TestSchemasUpToDate makes sure the committed schemas match the models.
+// Regenerate them with 'argus schema --dir schemas'.
+func TestSchemasUpToDate(t *testing.T) {
+	for _, k := range Kinds {
+		t.Run(k.Name, func(t *testing.T) {
+			expected, err := json.MarshalIndent(Generate(k), "", "  ")
+			assert.NilError(t, err)
+			actual, err := os.ReadFile(filepath.Join("..", "..", "schemas", strings.ToLower(k.Name)+".json"))
+			assert.NilError(t, err)
+			assert.Equal(t, string(expected)+"\n", string(actual))
+		})
+	}
+}
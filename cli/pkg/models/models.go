@@ -2,15 +2,31 @@ package models
 
 import "time"
 
-// add json labels to the following structures
+// Kinds of configuration documents, as set in their `kind` field.
+const (
+	KindResource       = "Resource"
+	KindRequirement    = "Requirement"
+	KindImplementation = "Implementation"
+	KindAttestation    = "Attestation"
+)
+
+// Resource holds its configuration along with the computed compliance state.
+// Compliant is Implemented, unless compliant descendants are required. Children and
+// Descendants count the resources below this one, and their compliant counterparts
+// how many of them are compliant.
 type Resource struct {
-	Name                    string   `json:"name"`
-	Type                    string   `json:"type"`
-	Classes                 []string `json:"classes"`
-	Parents                 []string `json:"parents"`
-	Requirements            map[string]RequirementBlock
-	ImplementedRequirements int
-	Implemented             bool
+	Name                    string                      `json:"name" jsonschema:"required"`
+	Type                    string                      `json:"type"`
+	Classes                 []string                    `json:"classes"`
+	Parents                 []string                    `json:"parents"`
+	Requirements            map[string]RequirementBlock `jsonschema:"-"`
+	ImplementedRequirements int                         `jsonschema:"-"`
+	Implemented             bool                        `jsonschema:"-"`
+	Compliant               bool                        `jsonschema:"-"`
+	Children                int                         `jsonschema:"-"`
+	CompliantChildren       int                         `jsonschema:"-"`
+	Descendants             int                         `jsonschema:"-"`
+	CompliantDescendants    int                         `jsonschema:"-"`
 }
 
 type RequirementBlock struct {
@@ -23,7 +39,10 @@ type RequirementBlock struct {
 }
 
 type ImplementationBlock struct {
-	Implementation       *Implementation
+	Implementation *Implementation
+	// InheritedFrom is the ancestor resource the implementation applies through.
+	// It is empty when the implementation references the resource itself.
+	InheritedFrom        string
 	Attestation          map[string]AttestationBlock
 	TotalAttestations    int
 	Attested             bool
@@ -39,41 +58,71 @@ type AttestationBlock struct {
 }
 
 type Requirement struct {
-	Name                          string   `json:"name"`
-	Version                       string   `json:"version"`
-	Code                          string   `json:"code"`
+	Name                          string   `json:"name" jsonschema:"required"`
+	Version                       string   `json:"version" jsonschema:"required"`
+	Code                          string   `json:"code" jsonschema:"required"`
 	Class                         string   `json:"class"`
 	Category                      string   `json:"category"`
+	Description                   string   `json:"description,omitempty"`
 	ApplicableResourceClasses     []string `json:"applicableResourceClasses"`
 	RequiredImplementationClasses []string `json:"requiredImplementationClasses"`
 }
 
 type RequirementRef struct {
-	Code    string `json:"code"`
-	Version string `json:"version"`
+	Code    string `json:"code" jsonschema:"required"`
+	Version string `json:"version" jsonschema:"required"`
 }
 type Implementation struct {
-	Name           string         `json:"name"`
+	Name           string         `json:"name" jsonschema:"required"`
 	Class          string         `json:"class"`
-	RequirementRef RequirementRef `json:"requirementRef"`
-	ResourceRef    []string       `json:"resourceRef"`
+	RequirementRef RequirementRef `json:"requirementRef" jsonschema:"required"`
+	ResourceRef    []string       `json:"resourceRef" jsonschema:"required"`
 }
 
 type Attestation struct {
-	Name              string               `json:"name"`
-	Type              string               `json:"type"`
-	Result            AttestationResult    `json:"result"`
+	Name              string               `json:"name" jsonschema:"required"`
+	Type              string               `json:"type" jsonschema:"required"`
+	Result            AttestationResult    `json:"result" jsonschema:"-"`
 	CommandRef        AttestationByCommand `json:"commandRef"`
-	ImplementationRef string               `json:"implementationRef"`
+	HTTPRef           AttestationByHTTP    `json:"httpRef"`
+	ImplementationRef string               `json:"implementationRef" jsonschema:"required"`
 }
 
 type AttestationByCommand struct {
-	Command          string   `json:"command"`
+	Command          string   `json:"command" jsonschema:"required"`
 	Args             []string `json:"args"`
 	ExpectedExitCode int      `json:"expectedExitCode"`
 	ExpectedOutput   string   `json:"expectedOutput,omitempty"`
 }
 
+type AttestationByHTTP struct {
+	Method             string              `json:"method,omitempty"`
+	URL                string              `json:"url" jsonschema:"required"`
+	Headers            map[string]string   `json:"headers,omitempty"`
+	Body               string              `json:"body,omitempty"`
+	Timeout            string              `json:"timeout,omitempty"`
+	TLS                HTTPTLSConfig       `json:"tls,omitempty"`
+	ExpectedStatusCode int                 `json:"expectedStatusCode,omitempty"`
+	ExpectedHeaders    map[string]string   `json:"expectedHeaders,omitempty"`
+	ExpectedBody       string              `json:"expectedBody,omitempty"`
+	ExpectedJSONPath   []JSONPathAssertion `json:"expectedJSONPath,omitempty"`
+}
+
+type HTTPTLSConfig struct {
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+	CAFile             string `json:"caFile,omitempty"`
+	CertFile           string `json:"certFile,omitempty"`
+	KeyFile            string `json:"keyFile,omitempty"`
+	ServerName         string `json:"serverName,omitempty"`
+}
+
+// JSONPathAssertion checks that the JSONPath expression resolves against the response body.
+// If Value is set, the resolved value must also be equal to it.
+type JSONPathAssertion struct {
+	Path  string `json:"path" jsonschema:"required"`
+	Value string `json:"value,omitempty"`
+}
+
 type Configuration struct {
 	Resources       []Resource       `json:"resources"`
 	Requirements    []Requirement    `json:"requirements"`
@@ -81,13 +130,18 @@ type Configuration struct {
 	Attestations    []Attestation    `json:"attestations"`
 }
 
+// ConfigFile is the argus CLI configuration. ConfigPath holds documents of any kind,
+// each tagged with a `kind` field. RequireCompliantDescendants makes a resource compliant
+// only when all of its descendants are.
 type ConfigFile struct {
-	ResourcePath       string                 `json:"resourcePath"`
-	RequirementPath    string                 `json:"requirementPath"`
-	ImplementationPath string                 `json:"implementationPath"`
-	AttestationPath    string                 `json:"attestationPath"`
-	Driver             string                 `json:"driver"`
-	DriverConfig       map[string]interface{} `json:"driverConfig"`
+	ConfigPath                  string                 `json:"configPath"`
+	ResourcePath                string                 `json:"resourcePath"`
+	RequirementPath             string                 `json:"requirementPath"`
+	ImplementationPath          string                 `json:"implementationPath"`
+	AttestationPath             string                 `json:"attestationPath"`
+	RequireCompliantDescendants bool                   `json:"requireCompliantDescendants"`
+	Driver                      string                 `json:"driver" jsonschema:"required"`
+	DriverConfig                map[string]interface{} `json:"driverConfig"`
 }
 
 type AttestationResult struct {
@@ -98,3 +152,33 @@ type AttestationResult struct {
 	Err     string
 	RunAt   time.Time
 }
+
+// Run is a single save of the configuration by a storage driver that keeps history.
+type Run struct {
+	ID      int64
+	SavedAt time.Time
+	// Results is the number of attestation results first recorded in this run.
+	Results int
+}
+
+// AttestationRecord is an attestation result as it was recorded in a given run.
+type AttestationRecord struct {
+	RunID              int64
+	Resource           string
+	Requirement        string
+	RequirementCode    string
+	RequirementVersion string
+	Implementation     string
+	Attestation        string
+	Type               string
+	Result             AttestationResult
+}
+
+// HistoryFilter narrows down the attestation records returned by a storage driver.
+// Empty fields match everything.
+type HistoryFilter struct {
+	Resource        string
+	RequirementCode string
+	Since           time.Time
+	Until           time.Time
+}
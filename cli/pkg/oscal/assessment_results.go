@@ -0,0 +1,157 @@
+package oscal
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Version is the OSCAL version of the documents argus writes.
+const Version = "1.1.2"
+
+// Namespace qualifies the names of the properties argus adds to OSCAL documents.
+const Namespace = "https://github.com/ContainerSolutions/argus"
+
+type AssessmentResultsDocument struct {
+	AssessmentResults AssessmentResults `json:"assessment-results"`
+}
+
+type AssessmentResults struct {
+	UUID     string   `json:"uuid"`
+	Metadata Metadata `json:"metadata"`
+	ImportAP ImportAP `json:"import-ap"`
+	Results  []Result `json:"results"`
+}
+
+// ImportAP references the assessment plan of the results.
+type ImportAP struct {
+	Href string `json:"href"`
+}
+
+type Result struct {
+	UUID             string            `json:"uuid"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	Start            string            `json:"start"`
+	End              string            `json:"end,omitempty"`
+	LocalDefinitions *LocalDefinitions `json:"local-definitions,omitempty"`
+	ReviewedControls ReviewedControls  `json:"reviewed-controls"`
+	Observations     []Observation     `json:"observations,omitempty"`
+	Findings         []Finding         `json:"findings,omitempty"`
+}
+
+type LocalDefinitions struct {
+	Components []SystemComponent `json:"components,omitempty"`
+}
+
+type SystemComponent struct {
+	UUID        string          `json:"uuid"`
+	Type        string          `json:"type"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Props       []Property      `json:"props,omitempty"`
+	Status      ComponentStatus `json:"status"`
+}
+
+type ComponentStatus struct {
+	State string `json:"state"`
+}
+
+type ReviewedControls struct {
+	ControlSelections []ControlSelection `json:"control-selections"`
+}
+
+// ControlSelection selects either all controls, or those of IncludeControls.
+type ControlSelection struct {
+	IncludeAll      *struct{}         `json:"include-all,omitempty"`
+	IncludeControls []SelectControlID `json:"include-controls,omitempty"`
+}
+
+type SelectControlID struct {
+	ControlID string `json:"control-id"`
+}
+
+type Observation struct {
+	UUID             string             `json:"uuid"`
+	Title            string             `json:"title,omitempty"`
+	Description      string             `json:"description"`
+	Props            []Property         `json:"props,omitempty"`
+	Methods          []string           `json:"methods"`
+	Subjects         []SubjectReference `json:"subjects,omitempty"`
+	RelevantEvidence []RelevantEvidence `json:"relevant-evidence,omitempty"`
+	Collected        string             `json:"collected"`
+}
+
+type SubjectReference struct {
+	SubjectUUID string `json:"subject-uuid"`
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+}
+
+type RelevantEvidence struct {
+	Description string `json:"description"`
+	Remarks     string `json:"remarks,omitempty"`
+}
+
+type Finding struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Props               []Property           `json:"props,omitempty"`
+	Target              FindingTarget        `json:"target"`
+	RelatedObservations []RelatedObservation `json:"related-observations,omitempty"`
+}
+
+type FindingTarget struct {
+	Type     string          `json:"type"`
+	TargetID string          `json:"target-id"`
+	Status   ObjectiveStatus `json:"status"`
+}
+
+type ObjectiveStatus struct {
+	State   string `json:"state"`
+	Reason  string `json:"reason,omitempty"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+type RelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+// uuidNamespace is the namespace of the name based UUIDs of argus.
+var uuidNamespace = [16]byte{0x6b, 0x3f, 0x1c, 0x52, 0x8e, 0x0d, 0x4a, 0x7b, 0x9c, 0x21, 0x5e, 0x43, 0xd6, 0x0a, 0x8f, 0x17}
+
+// UUID returns the version 5 UUID of a name, so that the same object always gets the same UUID.
+func UUID(name string) string {
+	h := sha1.New()
+	h.Write(uuidNamespace[:])
+	h.Write([]byte(name))
+	u := h.Sum(nil)[:16]
+	u[6] = (u[6] & 0x0f) | 0x50
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+var invalidToken = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+var tokenStart = regexp.MustCompile(`^[\p{L}_]`)
+
+// Token turns a code into an OSCAL token, as control ids must be.
+// Characters tokens cannot hold become '_', and tokens not starting with a letter get a '_' prefix.
+func Token(code string) string {
+	token := invalidToken.ReplaceAllString(code, "_")
+	if token == "" || !tokenStart.MatchString(token) {
+		token = "_" + token
+	}
+	return token
+}
+
+// Prop returns an argus property. OSCAL property values cannot span several lines nor be blank,
+// so whitespace is collapsed and blank values become "none".
+func Prop(name, value string) Property {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		value = "none"
+	}
+	return Property{Name: name, Value: value, NS: Namespace}
+}
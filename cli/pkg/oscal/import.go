@@ -0,0 +1,192 @@
+package oscal
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ImportOptions sets what OSCAL does not hold on the imported Requirements and Controls.
+type ImportOptions struct {
+	// Version overrides the version of the catalog.
+	Version string
+	// Class overrides the class of the controls.
+	Class string
+	// ResourceClasses are the classes of the resources the Requirements apply to.
+	ResourceClasses []string
+	// ImplementationClasses are the classes of the implementations the Requirements need.
+	ImplementationClasses []string
+}
+
+// Requirements maps catalog controls to Requirements. Control ids become codes, the catalog version
+// the version, and the title of the group of each control its category.
+func Requirements(catalog *Catalog, entries []Entry, opts ImportOptions) []models.Requirement {
+	reqs := []models.Requirement{}
+	for _, entry := range entries {
+		reqs = append(reqs, models.Requirement{
+			Name:                          entry.Control.Title,
+			Version:                       version(catalog, opts),
+			Code:                          entry.Control.ID,
+			Class:                         class(entry, opts),
+			Category:                      entry.Group,
+			Description:                   entry.Statement,
+			ApplicableResourceClasses:     nonNil(opts.ResourceClasses),
+			RequiredImplementationClasses: nonNil(opts.ImplementationClasses),
+		})
+	}
+	return reqs
+}
+
+// Manifest is a Kubernetes object for the argus operator.
+type Manifest struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   ManifestMetadata `json:"metadata"`
+	Spec       interface{}      `json:"spec"`
+}
+
+type ManifestMetadata struct {
+	Name string `json:"name"`
+}
+
+type ControlSpec struct {
+	Definition                 ControlDefinition `json:"definition"`
+	ApplicableComponentClasses []string          `json:"applicableComponentClasses"`
+	RequiredAssessmentClasses  []string          `json:"requiredAssessmentClasses"`
+}
+
+type ControlDefinition struct {
+	Version     string `json:"version"`
+	Code        string `json:"code"`
+	Class       string `json:"class"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+type FrameworkSpec struct {
+	Title       string       `json:"title,omitempty"`
+	Version     string       `json:"version"`
+	ControlRefs []ControlRef `json:"controlRefs"`
+}
+
+type ControlRef struct {
+	Code    string `json:"code"`
+	Version string `json:"version"`
+}
+
+const apiVersion = "argus.io/v1alpha1"
+
+// Controls maps catalog controls to the Control manifests of the operator, the same way as Requirements.
+// Manifests are named after the control id only, so that importing a newer catalog updates them in place.
+// If profile is set, a Framework grouping the Controls is appended.
+func Controls(catalog *Catalog, profile *Profile, entries []Entry, opts ImportOptions) []Manifest {
+	manifests := []Manifest{}
+	refs := []ControlRef{}
+	for _, entry := range entries {
+		manifests = append(manifests, Manifest{
+			APIVersion: apiVersion,
+			Kind:       "Control",
+			Metadata:   ManifestMetadata{Name: Name(entry.Control.ID)},
+			Spec: ControlSpec{
+				Definition: ControlDefinition{
+					Version:     version(catalog, opts),
+					Code:        entry.Control.ID,
+					Class:       class(entry, opts),
+					Category:    entry.Group,
+					Description: entry.Statement,
+				},
+				ApplicableComponentClasses: nonNil(opts.ResourceClasses),
+				RequiredAssessmentClasses:  nonNil(opts.ImplementationClasses),
+			},
+		})
+		refs = append(refs, ControlRef{Code: entry.Control.ID, Version: version(catalog, opts)})
+	}
+	if profile != nil {
+		manifests = append(manifests, Manifest{
+			APIVersion: apiVersion,
+			Kind:       "Framework",
+			Metadata:   ManifestMetadata{Name: Name(profile.Metadata.Title)},
+			Spec: FrameworkSpec{
+				Title:       profile.Metadata.Title,
+				Version:     profile.Metadata.Version,
+				ControlRefs: refs,
+			},
+		})
+	}
+	return manifests
+}
+
+var invalidName = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Name turns an OSCAL id or title into a valid Kubernetes object name.
+func Name(id string) string {
+	return strings.Trim(invalidName.ReplaceAllString(strings.ToLower(id), "-"), "-.")
+}
+
+// WriteRequirements writes Requirements as a multi document YAML file, each tagged with its kind.
+func WriteRequirements(w io.Writer, reqs []models.Requirement) error {
+	docs := []interface{}{}
+	for _, req := range reqs {
+		docs = append(docs, struct {
+			Kind string `json:"kind"`
+			models.Requirement
+		}{Kind: models.KindRequirement, Requirement: req})
+	}
+	return writeDocuments(w, docs)
+}
+
+// WriteManifests writes manifests as a multi document YAML file.
+func WriteManifests(w io.Writer, manifests []Manifest) error {
+	docs := []interface{}{}
+	for _, manifest := range manifests {
+		docs = append(docs, manifest)
+	}
+	return writeDocuments(w, docs)
+}
+
+func writeDocuments(w io.Writer, docs []interface{}) error {
+	for i, doc := range docs {
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("could not encode document %v: %w", i, err)
+		}
+		if i > 0 {
+			_, err = io.WriteString(w, "---\n")
+			if err != nil {
+				return err
+			}
+		}
+		_, err = w.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func version(catalog *Catalog, opts ImportOptions) string {
+	if opts.Version != "" {
+		return opts.Version
+	}
+	return catalog.Metadata.Version
+}
+
+func class(entry Entry, opts ImportOptions) string {
+	if opts.Class != "" {
+		return opts.Class
+	}
+	return entry.Control.Class
+}
+
+// nonNil keeps empty lists as lists in the YAML output.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
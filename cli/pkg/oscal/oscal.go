@@ -0,0 +1,278 @@
+package oscal
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Document is an OSCAL document, of which argus only reads the fields it needs.
+// See https://pages.nist.gov/OSCAL/reference/1.1.2/ for the full models. Only one of its fields is set.
+type Document struct {
+	Catalog *Catalog `json:"catalog,omitempty"`
+	Profile *Profile `json:"profile,omitempty"`
+}
+
+type Metadata struct {
+	Title        string `json:"title"`
+	LastModified string `json:"last-modified"`
+	Version      string `json:"version"`
+	OSCALVersion string `json:"oscal-version"`
+}
+
+type Catalog struct {
+	UUID     string    `json:"uuid"`
+	Metadata Metadata  `json:"metadata"`
+	Params   []Param   `json:"params,omitempty"`
+	Controls []Control `json:"controls,omitempty"`
+	Groups   []Group   `json:"groups,omitempty"`
+}
+
+type Group struct {
+	ID       string    `json:"id,omitempty"`
+	Class    string    `json:"class,omitempty"`
+	Title    string    `json:"title"`
+	Params   []Param   `json:"params,omitempty"`
+	Parts    []Part    `json:"parts,omitempty"`
+	Groups   []Group   `json:"groups,omitempty"`
+	Controls []Control `json:"controls,omitempty"`
+}
+
+// Control is an OSCAL control. Controls holds its enhancements.
+type Control struct {
+	ID       string     `json:"id"`
+	Class    string     `json:"class,omitempty"`
+	Title    string     `json:"title"`
+	Params   []Param    `json:"params,omitempty"`
+	Props    []Property `json:"props,omitempty"`
+	Parts    []Part     `json:"parts,omitempty"`
+	Controls []Control  `json:"controls,omitempty"`
+}
+
+type Param struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+}
+
+type Property struct {
+	Name  string `json:"name"`
+	NS    string `json:"ns,omitempty"`
+	Value string `json:"value"`
+}
+
+type Part struct {
+	ID    string     `json:"id,omitempty"`
+	Name  string     `json:"name"`
+	Props []Property `json:"props,omitempty"`
+	Prose string     `json:"prose,omitempty"`
+	Parts []Part     `json:"parts,omitempty"`
+}
+
+type Profile struct {
+	UUID     string   `json:"uuid"`
+	Metadata Metadata `json:"metadata"`
+	Imports  []Import `json:"imports"`
+}
+
+type Import struct {
+	Href            string      `json:"href"`
+	IncludeAll      *struct{}   `json:"include-all,omitempty"`
+	IncludeControls []Selection `json:"include-controls,omitempty"`
+	ExcludeControls []Selection `json:"exclude-controls,omitempty"`
+}
+
+// Selection selects controls by id. WithChildControls set to "yes" also selects their enhancements.
+type Selection struct {
+	WithChildControls string   `json:"with-child-controls,omitempty"`
+	WithIDs           []string `json:"with-ids,omitempty"`
+}
+
+// Read reads an OSCAL document in JSON or YAML.
+func Read(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc := Document{}
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode '%v': %w", path, err)
+	}
+	if doc.Catalog == nil && doc.Profile == nil {
+		return nil, fmt.Errorf("'%v' is neither an OSCAL catalog nor an OSCAL profile", path)
+	}
+	return &doc, nil
+}
+
+// Entry is a control of a catalog, along with the title of the group it belongs to.
+type Entry struct {
+	Control Control
+	Group   string
+	// Statement is the prose of the control statement, with its parameters replaced by their labels.
+	Statement string
+}
+
+// Select returns the controls of a catalog that a profile includes, in catalog order.
+// Enhancements follow the control they enhance. Withdrawn controls are left out.
+// A nil profile includes every control.
+func Select(catalog *Catalog, profile *Profile) ([]Entry, error) {
+	labels := map[string]string{}
+	addParams(labels, catalog.Params)
+	entries := []Entry{}
+	parents := map[string]string{}
+	var walkControls func(controls []Control, group, parent string)
+	walkControls = func(controls []Control, group, parent string) {
+		for _, control := range controls {
+			parents[control.ID] = parent
+			addParams(labels, control.Params)
+			if !Withdrawn(control) {
+				entries = append(entries, Entry{Control: control, Group: group})
+			}
+			walkControls(control.Controls, group, control.ID)
+		}
+	}
+	var walkGroups func(groups []Group)
+	walkGroups = func(groups []Group) {
+		for _, group := range groups {
+			addParams(labels, group.Params)
+			walkControls(group.Controls, group.Title, "")
+			walkGroups(group.Groups)
+		}
+	}
+	walkControls(catalog.Controls, "", "")
+	walkGroups(catalog.Groups)
+	for i := range entries {
+		entries[i].Statement = statement(entries[i].Control, labels)
+	}
+	if profile == nil {
+		return entries, nil
+	}
+	included, err := include(profile, parents)
+	if err != nil {
+		return nil, err
+	}
+	selected := []Entry{}
+	for _, entry := range entries {
+		if included[entry.Control.ID] {
+			selected = append(selected, entry)
+		}
+	}
+	return selected, nil
+}
+
+// Withdrawn reports whether a control was withdrawn from its catalog.
+func Withdrawn(control Control) bool {
+	for _, prop := range control.Props {
+		if prop.Name == "status" && prop.Value == "withdrawn" {
+			return true
+		}
+	}
+	return false
+}
+
+// include returns the ids of the controls a profile includes. parents maps the id of every control
+// of the catalog to the id of the control it enhances, if any.
+func include(profile *Profile, parents map[string]string) (map[string]bool, error) {
+	children := map[string][]string{}
+	for id, parent := range parents {
+		if parent != "" {
+			children[parent] = append(children[parent], id)
+		}
+	}
+	var mark func(set map[string]bool, selection Selection) error
+	mark = func(set map[string]bool, selection Selection) error {
+		for _, id := range selection.WithIDs {
+			if _, ok := parents[id]; !ok {
+				return fmt.Errorf("profile selects control '%v', which is not in the catalog", id)
+			}
+			set[id] = true
+			if selection.WithChildControls == "yes" {
+				err := mark(set, Selection{WithChildControls: "yes", WithIDs: children[id]})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	included := map[string]bool{}
+	for _, imp := range profile.Imports {
+		set := map[string]bool{}
+		if imp.IncludeAll != nil || len(imp.IncludeControls) == 0 {
+			for id := range parents {
+				set[id] = true
+			}
+		}
+		for _, selection := range imp.IncludeControls {
+			err := mark(set, selection)
+			if err != nil {
+				return nil, err
+			}
+		}
+		excluded := map[string]bool{}
+		for _, selection := range imp.ExcludeControls {
+			err := mark(excluded, selection)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for id := range set {
+			if !excluded[id] {
+				included[id] = true
+			}
+		}
+	}
+	return included, nil
+}
+
+func addParams(labels map[string]string, params []Param) {
+	for _, param := range params {
+		labels[param.ID] = param.Label
+	}
+}
+
+var insertParam = regexp.MustCompile(`{{\s*insert:\s*param,\s*([^\s}]+)\s*}}`)
+
+// statement flattens the prose of the statement part of a control, one paragraph per line.
+// Parameter insertions are replaced by the label of the parameter.
+func statement(control Control, labels map[string]string) string {
+	lines := []string{}
+	var walk func(parts []Part)
+	walk = func(parts []Part) {
+		for _, part := range parts {
+			prose := strings.TrimSpace(part.Prose)
+			if prose != "" {
+				if label := partLabel(part); label != "" {
+					prose = label + " " + prose
+				}
+				lines = append(lines, prose)
+			}
+			walk(part.Parts)
+		}
+	}
+	for _, part := range control.Parts {
+		if part.Name == "statement" {
+			walk([]Part{part})
+		}
+	}
+	text := strings.Join(lines, "\n")
+	return insertParam.ReplaceAllStringFunc(text, func(match string) string {
+		id := insertParam.FindStringSubmatch(match)[1]
+		if label, ok := labels[id]; ok && label != "" {
+			return "[" + label + "]"
+		}
+		return "[" + id + "]"
+	})
+}
+
+func partLabel(part Part) string {
+	for _, prop := range part.Props {
+		if prop.Name == "label" {
+			return prop.Value
+		}
+	}
+	return ""
+}
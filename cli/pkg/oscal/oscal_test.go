@@ -0,0 +1,254 @@
+package oscal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/parser"
+
+	"gotest.tools/v3/assert"
+)
+
+const catalogJSON = `{
+  "catalog": {
+    "uuid": "74c8ba1e-5cd4-4ad1-bbfd-d888e2f6c724",
+    "metadata": {"title": "Example catalog", "last-modified": "2023-06-01T00:00:00Z", "version": "5.1.0", "oscal-version": "1.1.2"},
+    "groups": [
+      {
+        "id": "ac",
+        "class": "family",
+        "title": "Access Control",
+        "controls": [
+          {
+            "id": "ac-1",
+            "class": "SP800-53",
+            "title": "Policy and Procedures",
+            "params": [{"id": "ac-1_prm_1", "label": "organization-defined personnel or roles"}],
+            "parts": [
+              {"id": "ac-1_smt", "name": "statement", "parts": [
+                {"id": "ac-1_smt.a", "name": "item", "props": [{"name": "label", "value": "a."}], "prose": "Develop and disseminate to {{ insert: param, ac-1_prm_1 }}:"},
+                {"id": "ac-1_smt.b", "name": "item", "props": [{"name": "label", "value": "b."}], "prose": "Review the policy."}
+              ]},
+              {"id": "ac-1_gdn", "name": "guidance", "prose": "Not part of the statement."}
+            ]
+          },
+          {
+            "id": "ac-2",
+            "class": "SP800-53",
+            "title": "Account Management",
+            "parts": [{"id": "ac-2_smt", "name": "statement", "prose": "Manage accounts."}],
+            "controls": [
+              {"id": "ac-2.1", "class": "SP800-53-enhancement", "title": "Automated Account Management", "parts": [{"id": "ac-2.1_smt", "name": "statement", "prose": "Automate it."}]},
+              {"id": "ac-2.2", "class": "SP800-53-enhancement", "title": "Withdrawn", "props": [{"name": "status", "value": "withdrawn"}]}
+            ]
+          }
+        ]
+      },
+      {
+        "id": "sc",
+        "class": "family",
+        "title": "System and Communications Protection",
+        "controls": [
+          {"id": "sc-7", "class": "SP800-53", "title": "Boundary Protection", "parts": [{"id": "sc-7_smt", "name": "statement", "prose": "Monitor the boundary."}]}
+        ]
+      }
+    ]
+  }
+}`
+
+const profileYAML = `profile:
+  uuid: 0f6c1a3e-0d8b-4bb4-9a4e-1f3b0c1d2e3f
+  metadata:
+    title: Low Baseline
+    last-modified: "2023-06-01T00:00:00Z"
+    version: 1.0.0
+    oscal-version: 1.1.2
+  imports:
+  - href: catalog.json
+    include-controls:
+    - with-child-controls: "yes"
+      with-ids: [ac-2]
+    - with-ids: [sc-7]
+`
+
+func readCatalog(t *testing.T, data string) *Catalog {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	assert.NilError(t, os.WriteFile(path, []byte(data), 0o600))
+	doc, err := Read(path)
+	assert.NilError(t, err)
+	assert.Assert(t, doc.Catalog != nil)
+	return doc.Catalog
+}
+
+func codes(entries []Entry) []string {
+	ids := []string{}
+	for _, entry := range entries {
+		ids = append(ids, entry.Control.ID)
+	}
+	return ids
+}
+
+func TestSelect(t *testing.T) {
+	catalog := readCatalog(t, catalogJSON)
+	entries, err := Select(catalog, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"ac-1", "ac-2", "ac-2.1", "sc-7"}, codes(entries))
+	assert.Equal(t, "Access Control", entries[2].Group)
+	assert.Equal(t, "a. Develop and disseminate to [organization-defined personnel or roles]:\nb. Review the policy.", entries[0].Statement)
+
+	testCases := []struct {
+		name          string
+		imports       []Import
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "include all",
+			imports:  []Import{{Href: "catalog.json", IncludeAll: &struct{}{}}},
+			expected: []string{"ac-1", "ac-2", "ac-2.1", "sc-7"},
+		},
+		{
+			name:     "include without child controls",
+			imports:  []Import{{Href: "catalog.json", IncludeControls: []Selection{{WithIDs: []string{"ac-2", "sc-7"}}}}},
+			expected: []string{"ac-2", "sc-7"},
+		},
+		{
+			name:     "include with child controls",
+			imports:  []Import{{Href: "catalog.json", IncludeControls: []Selection{{WithChildControls: "yes", WithIDs: []string{"ac-2"}}}}},
+			expected: []string{"ac-2", "ac-2.1"},
+		},
+		{
+			name:     "exclude",
+			imports:  []Import{{Href: "catalog.json", IncludeAll: &struct{}{}, ExcludeControls: []Selection{{WithIDs: []string{"ac-1"}}}}},
+			expected: []string{"ac-2", "ac-2.1", "sc-7"},
+		},
+		{
+			name:          "unknown control",
+			imports:       []Import{{Href: "catalog.json", IncludeControls: []Selection{{WithIDs: []string{"ac-99"}}}}},
+			expectedError: "profile selects control 'ac-99', which is not in the catalog",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := Select(catalog, &Profile{Imports: tc.imports})
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, codes(entries))
+		})
+	}
+}
+
+func TestRequirements(t *testing.T) {
+	catalog := readCatalog(t, catalogJSON)
+	entries, err := Select(catalog, nil)
+	assert.NilError(t, err)
+	reqs := Requirements(catalog, entries, ImportOptions{Class: "Security", ResourceClasses: []string{"VirtualMachine"}})
+	assert.DeepEqual(t, models.Requirement{
+		Name:                          "Boundary Protection",
+		Version:                       "5.1.0",
+		Code:                          "sc-7",
+		Class:                         "Security",
+		Category:                      "System and Communications Protection",
+		Description:                   "Monitor the boundary.",
+		ApplicableResourceClasses:     []string{"VirtualMachine"},
+		RequiredImplementationClasses: []string{},
+	}, reqs[3])
+
+	// Imported requirements must be read back by the configuration parser.
+	dir := t.TempDir()
+	var b bytes.Buffer
+	assert.NilError(t, WriteRequirements(&b, reqs))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "requirements.yaml"), b.Bytes(), 0o600))
+	parsed, err := parser.ParseRequirements(dir)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, reqs, parsed)
+}
+
+func TestReimport(t *testing.T) {
+	write := func(catalog *Catalog) []string {
+		entries, err := Select(catalog, nil)
+		assert.NilError(t, err)
+		var b bytes.Buffer
+		assert.NilError(t, WriteRequirements(&b, Requirements(catalog, entries, ImportOptions{})))
+		return strings.Split(b.String(), "\n")
+	}
+	before := write(readCatalog(t, catalogJSON))
+	after := write(readCatalog(t, strings.Replace(catalogJSON, "Monitor the boundary.", "Monitor and control the boundary.", 1)))
+	assert.Equal(t, len(before), len(after))
+	changed := []string{}
+	for i := range before {
+		if before[i] != after[i] {
+			changed = append(changed, after[i])
+		}
+	}
+	assert.DeepEqual(t, []string{"description: Monitor and control the boundary."}, changed)
+}
+
+func TestControls(t *testing.T) {
+	catalog := readCatalog(t, catalogJSON)
+	path := filepath.Join(t.TempDir(), "profile.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(profileYAML), 0o600))
+	doc, err := Read(path)
+	assert.NilError(t, err)
+	entries, err := Select(catalog, doc.Profile)
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	assert.NilError(t, WriteManifests(&b, Controls(catalog, doc.Profile, entries, ImportOptions{Version: "5.1.1"})))
+	docs := strings.Split(b.String(), "---\n")
+	assert.Equal(t, 4, len(docs))
+	assert.Equal(t, `apiVersion: argus.io/v1alpha1
+kind: Control
+metadata:
+  name: ac-2.1
+spec:
+  applicableComponentClasses: []
+  definition:
+    category: Access Control
+    class: SP800-53-enhancement
+    code: ac-2.1
+    description: Automate it.
+    version: 5.1.1
+  requiredAssessmentClasses: []
+`, docs[1])
+	assert.Equal(t, `apiVersion: argus.io/v1alpha1
+kind: Framework
+metadata:
+  name: low-baseline
+spec:
+  controlRefs:
+  - code: ac-2
+    version: 5.1.1
+  - code: ac-2.1
+    version: 5.1.1
+  - code: sc-7
+    version: 5.1.1
+  title: Low Baseline
+  version: 1.0.0
+`, docs[3])
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "ac-2.1", Name("AC-2.1"))
+	assert.Equal(t, "cis-kubernetes-benchmark-1.8", Name("CIS Kubernetes Benchmark (1.8)"))
+}
+
+func TestUUID(t *testing.T) {
+	assert.Equal(t, UUID("resource/vm"), UUID("resource/vm"))
+	assert.Assert(t, UUID("resource/vm") != UUID("resource/db"))
+	assert.Assert(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(UUID("resource/vm")))
+}
+
+func TestToken(t *testing.T) {
+	assert.Equal(t, "REQ-01", Token("REQ-01"))
+	assert.Equal(t, "ac-2.1", Token("ac-2.1"))
+	assert.Equal(t, "_1.2.1", Token("1.2.1"))
+	assert.Equal(t, "SEC_POL_01", Token("SEC POL/01"))
+}
@@ -1,147 +1,234 @@
 package parser
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 
+	"github.com/ContainerSolutions/argus/cli/pkg/jsonschema"
 	"github.com/ContainerSolutions/argus/cli/pkg/models"
 
 	"sigs.k8s.io/yaml"
 )
 
+// Sources holds the file every parsed object was read from.
+// Each slice is indexed like its counterpart in models.Configuration.
+type Sources struct {
+	Resources       []string
+	Requirements    []string
+	Implementations []string
+	Attestations    []string
+}
+
+// separator splits yaml streams into documents.
+var separator = regexp.MustCompile(`(?m)^---[ \t]*(#.*)?$`)
+
+var extensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// errMissingKind is returned for documents under ConfigPath without a kind. They are skipped,
+// so that other files, such as the CLI config file itself, can live next to the configuration.
+var errMissingKind = errors.New("missing field \"kind\"")
+
+// warnings receives the documents that are skipped while parsing.
+var warnings io.Writer = os.Stderr
+
 func Parse(config *models.ConfigFile) (*models.Configuration, error) {
+	c, _, err := ParseWithSources(config)
+	return c, err
+}
+
+// ParseWithSources parses the configuration and keeps track of the file each object came from.
+// Documents under ConfigPath without a kind are skipped with a warning. Documents under the per kind paths may omit it.
+func ParseWithSources(config *models.ConfigFile) (*models.Configuration, *Sources, error) {
 	c := models.Configuration{}
-	var err error
-	c.Attestations, err = ParseAttestations(config.AttestationPath)
-	if err != nil {
-		return nil, err
+	s := Sources{}
+	paths := []struct {
+		path string
+		kind string
+	}{
+		{config.ConfigPath, ""},
+		{config.AttestationPath, models.KindAttestation},
+		{config.RequirementPath, models.KindRequirement},
+		{config.ResourcePath, models.KindResource},
+		{config.ImplementationPath, models.KindImplementation},
 	}
-	c.Requirements, err = ParseRequirements(config.RequirementPath)
-	if err != nil {
-		return nil, err
-	}
-	c.Resources, err = ParseResources(config.ResourcePath)
-	if err != nil {
-		return nil, err
-	}
-	c.Implementations, err = ParseImplementations(config.ImplementationPath)
-	if err != nil {
-		return nil, err
+	for _, p := range paths {
+		if p.path == "" {
+			continue
+		}
+		err := parseTree(p.path, p.kind, &c, &s)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
-	return &c, nil
+	return &c, &s, nil
 }
 
 func ParseResources(config string) ([]models.Resource, error) {
-	resources := []models.Resource{}
-	walkFn := func(path string, info fs.FileInfo, err error) error {
-		if filepath.Ext(path) == ".yaml" {
-			yamlFile, err := os.Open(path)
-			if err != nil {
-				return err
-			}
+	c := models.Configuration{}
+	err := parseTree(config, models.KindResource, &c, &Sources{})
+	return c.Resources, err
+}
 
-			defer yamlFile.Close()
+func ParseRequirements(config string) ([]models.Requirement, error) {
+	c := models.Configuration{}
+	err := parseTree(config, models.KindRequirement, &c, &Sources{})
+	return c.Requirements, err
+}
 
-			byteValue, _ := io.ReadAll(yamlFile)
+func ParseImplementations(config string) ([]models.Implementation, error) {
+	c := models.Configuration{}
+	err := parseTree(config, models.KindImplementation, &c, &Sources{})
+	return c.Implementations, err
+}
 
-			var resource models.Resource
-			if err := yaml.Unmarshal(byteValue, &resource); err != nil {
-				return fmt.Errorf("Could not unmarshal file %s: %w", path, err)
-			}
-			resources = append(resources, resource)
-		}
-		return nil
-	}
-	err := filepath.Walk(config, walkFn)
-	if err != nil {
-		return nil, err
-	}
-	return resources, nil
+func ParseAttestations(config string) ([]models.Attestation, error) {
+	c := models.Configuration{}
+	err := parseTree(config, models.KindAttestation, &c, &Sources{})
+	return c.Attestations, err
 }
 
-func ParseRequirements(config string) ([]models.Requirement, error) {
-	res := []models.Requirement{}
+// parseTree reads every yaml and json document under root into c.
+// If kind is set, documents without a kind are read as that kind and documents of other kinds are rejected.
+func parseTree(root, kind string, c *models.Configuration, s *Sources) error {
 	walkFn := func(path string, info fs.FileInfo, err error) error {
-		if filepath.Ext(path) == ".yaml" {
-			yamlFile, err := os.Open(path)
-			if err != nil {
-				return err
+		if !extensions[filepath.Ext(path)] {
+			return nil
+		}
+		docs, err := readDocuments(path)
+		if err != nil {
+			return err
+		}
+		for i, doc := range docs {
+			source := path
+			if len(docs) > 1 {
+				source = fmt.Sprintf("%v (document %v)", path, i+1)
 			}
-
-			defer yamlFile.Close()
-
-			byteValue, _ := io.ReadAll(yamlFile)
-
-			var p models.Requirement
-			if err := yaml.Unmarshal(byteValue, &p); err != nil {
-				return fmt.Errorf("Could not unmarshal file %s: %w", path, err)
+			err := add(doc, kind, source, c, s)
+			if errors.Is(err, errMissingKind) {
+				fmt.Fprintf(warnings, "skipping %v: %v\n", source, err)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("Could not unmarshal file %s: %w", source, err)
 			}
-			res = append(res, p)
 		}
 		return nil
 	}
-	err := filepath.Walk(config, walkFn)
+	return filepath.Walk(root, walkFn)
+}
+
+func add(doc []byte, kind, source string, c *models.Configuration, s *Sources) error {
+	docKind, doc, err := splitKind(doc)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	switch {
+	case docKind == "" && kind == "":
+		return errMissingKind
+	case docKind == "":
+		docKind = kind
+	case kind != "" && docKind != kind:
+		return fmt.Errorf("kind %q is not allowed where %v documents are expected", docKind, kind)
+	}
+	switch docKind {
+	case models.KindResource:
+		return decodeInto(doc, source, &c.Resources, &s.Resources)
+	case models.KindRequirement:
+		return decodeInto(doc, source, &c.Requirements, &s.Requirements)
+	case models.KindImplementation:
+		return decodeInto(doc, source, &c.Implementations, &s.Implementations)
+	case models.KindAttestation:
+		return decodeInto(doc, source, &c.Attestations, &s.Attestations)
+	default:
+		return fmt.Errorf("unknown kind %q", docKind)
 	}
-	return res, nil
 }
 
-func ParseImplementations(config string) ([]models.Implementation, error) {
-	res := []models.Implementation{}
-	walkFn := func(path string, info fs.FileInfo, err error) error {
-		if filepath.Ext(path) == ".yaml" {
-			yamlFile, err := os.Open(path)
-			if err != nil {
-				return err
-			}
-
-			defer yamlFile.Close()
-
-			byteValue, _ := io.ReadAll(yamlFile)
+func decodeInto[T any](doc []byte, source string, res *[]T, sources *[]string) error {
+	var p T
+	err := decode(doc, &p)
+	if err != nil {
+		return err
+	}
+	*res = append(*res, p)
+	*sources = append(*sources, source)
+	return nil
+}
 
-			var p models.Implementation
-			if err := yaml.Unmarshal(byteValue, &p); err != nil {
-				return fmt.Errorf("Could not unmarshal file %s: %w", path, err)
-			}
-			res = append(res, p)
-		}
-		return nil
+// readDocuments returns every non empty document of a file as json.
+// yaml files may hold several documents separated by '---'. json files may hold an array of documents.
+func readDocuments(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
 	}
-	err := filepath.Walk(config, walkFn)
+	defer f.Close()
+	byteValue, err := io.ReadAll(f)
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
-}
-
-func ParseAttestations(config string) ([]models.Attestation, error) {
-	res := []models.Attestation{}
-	walkFn := func(path string, info fs.FileInfo, err error) error {
-		if filepath.Ext(path) == ".yaml" {
-			yamlFile, err := os.Open(path)
+	docs := [][]byte{}
+	if filepath.Ext(path) == ".json" {
+		trimmed := bytes.TrimSpace(byteValue)
+		if len(trimmed) > 0 && trimmed[0] == '[' {
+			items := []json.RawMessage{}
+			err := json.Unmarshal(trimmed, &items)
 			if err != nil {
-				return err
+				return nil, fmt.Errorf("Could not unmarshal file %s: %w", path, err)
 			}
-
-			defer yamlFile.Close()
-
-			byteValue, _ := io.ReadAll(yamlFile)
-
-			var p models.Attestation
-			if err := yaml.Unmarshal(byteValue, &p); err != nil {
-				return fmt.Errorf("Could not unmarshal file %s: %w", path, err)
+			for _, item := range items {
+				docs = append(docs, item)
 			}
-			res = append(res, p)
+			return docs, nil
 		}
-		return nil
 	}
-	err := filepath.Walk(config, walkFn)
+	for _, part := range separator.Split(string(byteValue), -1) {
+		j, err := yaml.YAMLToJSON([]byte(part))
+		if err != nil {
+			return nil, fmt.Errorf("Could not unmarshal file %s: %w", path, err)
+		}
+		if string(j) == "null" {
+			continue
+		}
+		docs = append(docs, j)
+	}
+	return docs, nil
+}
+
+// splitKind returns the kind of a json document, along with the document without its kind field.
+func splitKind(doc []byte) (string, []byte, error) {
+	obj := map[string]json.RawMessage{}
+	err := json.Unmarshal(doc, &obj)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+	raw, ok := obj["kind"]
+	if !ok {
+		return "", doc, nil
+	}
+	var kind string
+	err = json.Unmarshal(raw, &kind)
+	if err != nil {
+		return "", nil, fmt.Errorf("field \"kind\" must be a string")
+	}
+	delete(obj, "kind")
+	doc, err = json.Marshal(obj)
+	return kind, doc, err
+}
+
+// decode unmarshals a json document into v, rejecting fields that v does not define.
+// Field names must match exactly.
+func decode(data []byte, v interface{}) error {
+	err := jsonschema.Check(data, v)
+	if err != nil {
+		return err
 	}
-	return res, nil
+	return json.Unmarshal(data, v)
 }
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestParseWithSourcesConfigPath(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":  "kind: Resource\nname: cloud\n---\n# only a comment\n---\nkind: Resource\nname: vm\nparents:\n- cloud\n",
+		"b/c.yml": "kind: Requirement\nname: req\ncode: REQ-01\nversion: 1.0.0\n",
+		"d.json": `[{"kind": "Implementation", "name": "imp", "requirementRef": {"code": "REQ-01", "version": "1.0.0"}, "resourceRef": ["cloud"]},
+			{"kind": "Attestation", "name": "att", "type": "command", "implementationRef": "imp", "commandRef": {"command": "true"}}]`,
+		"e.json":    `{"kind": "Resource", "name": "db"}`,
+		"notes.txt": "kind: Resource\nname: ignored\n",
+	})
+	c, s, err := ParseWithSources(&models.ConfigFile{ConfigPath: dir})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"cloud", "vm", "db"}, []string{c.Resources[0].Name, c.Resources[1].Name, c.Resources[2].Name})
+	assert.DeepEqual(t, []string{"cloud"}, c.Resources[1].Parents)
+	assert.Equal(t, "REQ-01", c.Requirements[0].Code)
+	assert.Equal(t, "imp", c.Implementations[0].Name)
+	assert.Equal(t, "true", c.Attestations[0].CommandRef.Command)
+	assert.DeepEqual(t, []string{
+		filepath.Join(dir, "a.yaml") + " (document 1)",
+		filepath.Join(dir, "a.yaml") + " (document 2)",
+		filepath.Join(dir, "e.json"),
+	}, s.Resources)
+	assert.DeepEqual(t, []string{filepath.Join(dir, "b", "c.yml")}, s.Requirements)
+	assert.DeepEqual(t, []string{filepath.Join(dir, "d.json") + " (document 2)"}, s.Attestations)
+}
+
+func TestParseWithSourcesLegacyPaths(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"resources/vm.yaml":        "name: vm\n",
+		"resources/more.yaml":      "name: a\n---\nkind: Resource\nname: b\n",
+		"requirements/req.yaml":    "name: req\ncode: REQ-01\nversion: 1.0.0\n",
+		"implementations/imp.yaml": "name: imp\nrequirementRef:\n  code: REQ-01\n  version: 1.0.0\nresourceRef:\n- vm\n",
+		"attestations/att.yaml":    "name: att\ntype: command\nimplementationRef: imp\n",
+		"multidoc/all.yaml":        "kind: Resource\nname: db\n",
+	})
+	c, err := Parse(&models.ConfigFile{
+		ConfigPath:         filepath.Join(dir, "multidoc"),
+		ResourcePath:       filepath.Join(dir, "resources"),
+		RequirementPath:    filepath.Join(dir, "requirements"),
+		ImplementationPath: filepath.Join(dir, "implementations"),
+		AttestationPath:    filepath.Join(dir, "attestations"),
+	})
+	assert.NilError(t, err)
+	names := []string{}
+	for _, r := range c.Resources {
+		names = append(names, r.Name)
+	}
+	assert.DeepEqual(t, []string{"db", "a", "b", "vm"}, names)
+	assert.Equal(t, 1, len(c.Requirements))
+	assert.Equal(t, 1, len(c.Implementations))
+	assert.Equal(t, 1, len(c.Attestations))
+}
+
+func TestParseSkipsMissingKind(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		".argus-config.yaml": "configPath: ./\ndb:\n  type: sqlite\n",
+		"a.yaml":             "kind: Resource\nname: vm\n---\nname: other\n",
+	})
+	var b bytes.Buffer
+	warnings = &b
+	t.Cleanup(func() { warnings = os.Stderr })
+	c, err := Parse(&models.ConfigFile{ConfigPath: dir})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(c.Resources))
+	assert.Equal(t, "vm", c.Resources[0].Name)
+	out := b.String()
+	assert.Assert(t, strings.Contains(out, `.argus-config.yaml: missing field "kind"`), out)
+	assert.Assert(t, strings.Contains(out, `a.yaml (document 2): missing field "kind"`), out)
+}
+
+func TestParseErrors(t *testing.T) {
+	testCase := []struct {
+		name          string
+		files         map[string]string
+		legacy        bool
+		expectedError string
+	}{
+		{
+			name:          "UnknownKind",
+			files:         map[string]string{"a.yaml": "kind: Resource\nname: vm\n---\nkind: Policy\nname: p\n"},
+			expectedError: `a.yaml (document 2): unknown kind "Policy"`,
+		},
+		{
+			name:          "WrongKindInLegacyPath",
+			files:         map[string]string{"a.yaml": "kind: Requirement\nname: req\ncode: REQ-01\nversion: 1.0.0\n"},
+			legacy:        true,
+			expectedError: `a.yaml: kind "Requirement" is not allowed where Resource documents are expected`,
+		},
+		{
+			name:          "UnknownField",
+			files:         map[string]string{"a.yml": "kind: Resource\nname: vm\nParents: []\n"},
+			expectedError: `a.yml: unknown field "Parents"`,
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeFiles(t, tc.files)
+			config := &models.ConfigFile{ConfigPath: dir}
+			if tc.legacy {
+				config = &models.ConfigFile{ResourcePath: dir}
+			}
+			_, err := Parse(config)
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+// CycleError is returned when resource parents form a cycle.
+type CycleError struct {
+	// Path lists the resources of the cycle, starting and ending with the same resource.
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("resource parents form a cycle: %v", strings.Join(e.Path, " -> "))
+}
+
+// Hierarchy is the resource graph built out of Resource.Parents.
+// Parents that do not match any resource are kept as ancestors without parents of their own,
+// so that implementations can still match them by name.
+type Hierarchy struct {
+	parents map[string][]string
+}
+
+// NewHierarchy builds the resource graph, failing if it is not acyclic.
+func NewHierarchy(resources []models.Resource) (*Hierarchy, error) {
+	h := &Hierarchy{parents: map[string][]string{}}
+	for _, r := range resources {
+		h.parents[r.Name] = []string{}
+	}
+	for _, r := range resources {
+		h.parents[r.Name] = append(h.parents[r.Name], r.Parents...)
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		path = append(path, name)
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for i, p := range path {
+				if p == name {
+					start = i
+					break
+				}
+			}
+			return &CycleError{Path: path[start:]}
+		}
+		state[name] = visiting
+		for _, p := range h.parents[name] {
+			err := visit(p, path)
+			if err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, r := range resources {
+		err := visit(r.Name, []string{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Ancestors lists every transitive parent of a resource, nearest first.
+func (h *Hierarchy) Ancestors(name string) []string {
+	ancestors := []string{}
+	seen := map[string]bool{name: true}
+	queue := append([]string{}, h.parents[name]...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		ancestors = append(ancestors, current)
+		queue = append(queue, h.parents[current]...)
+	}
+	return ancestors
+}
+
+// Children lists the resources that have the given resource as a direct parent, sorted by name.
+func (h *Hierarchy) Children(name string) []string {
+	children := []string{}
+	for child, parents := range h.parents {
+		if utils.Contains(parents, name) {
+			children = append(children, child)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// Descendants lists every transitive child of a resource, sorted by name.
+func (h *Hierarchy) Descendants(name string) []string {
+	descendants := []string{}
+	seen := map[string]bool{name: true}
+	queue := h.Children(name)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		descendants = append(descendants, current)
+		queue = append(queue, h.Children(current)...)
+	}
+	sort.Strings(descendants)
+	return descendants
+}
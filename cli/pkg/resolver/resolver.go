@@ -26,8 +26,12 @@ func ResolveRequirements(config *models.Configuration) (*models.Configuration, e
 }
 
 func ResolveImplementations(config *models.Configuration) (*models.Configuration, error) {
+	h, err := NewHierarchy(config.Resources)
+	if err != nil {
+		return nil, err
+	}
 	for k, r := range config.Resources {
-		resolveImpForResource(&r, config.Implementations)
+		resolveImpForResource(&r, h.Ancestors(r.Name), config.Implementations)
 		config.Resources[k] = r
 	}
 	return config, nil
@@ -62,23 +66,43 @@ func resolveAttForResource(current *models.Resource, attestations []models.Attes
 		}
 	}
 }
-func resolveImpForResource(current *models.Resource, implementations []models.Implementation) {
+
+// resolveImpForResource adds the implementations referencing the resource or any of its ancestors.
+// ancestors must be sorted nearest first; an implementation is inherited from the nearest one it references.
+func resolveImpForResource(current *models.Resource, ancestors []string, implementations []models.Implementation) {
 	for ai, implementation := range implementations {
+		inheritedFrom, ok := matchResource(current.Name, ancestors, implementation.ResourceRef)
+		if !ok {
+			continue
+		}
 		for k, reqBlock := range current.Requirements {
 			requirement := reqBlock.Requirement
-			for _, ref := range implementation.ResourceRef {
-				if implementation.RequirementRef.Code == requirement.Code && implementation.RequirementRef.Version == requirement.Version && (ref == current.Name || utils.Contains(current.Parents, ref)) {
-					if reqBlock.Implementations == nil {
-						reqBlock.Implementations = make(map[string]models.ImplementationBlock)
-					}
-					reqBlock.Implementations[implementation.Name] = models.ImplementationBlock{
-						Implementation: &implementations[ai],
-					}
+			if implementation.RequirementRef.Code == requirement.Code && implementation.RequirementRef.Version == requirement.Version {
+				if reqBlock.Implementations == nil {
+					reqBlock.Implementations = make(map[string]models.ImplementationBlock)
+				}
+				reqBlock.Implementations[implementation.Name] = models.ImplementationBlock{
+					Implementation: &implementations[ai],
+					InheritedFrom:  inheritedFrom,
 				}
-				current.Requirements[k] = reqBlock
 			}
+			current.Requirements[k] = reqBlock
+		}
+	}
+}
+
+// matchResource returns the resource the references apply through: "" for the resource itself,
+// or the nearest matching ancestor.
+func matchResource(name string, ancestors []string, refs []string) (string, bool) {
+	if utils.Contains(refs, name) {
+		return "", true
+	}
+	for _, ancestor := range ancestors {
+		if utils.Contains(refs, ancestor) {
+			return ancestor, true
 		}
 	}
+	return "", false
 }
 func resolveReqForResource(current *models.Resource, requirements []models.Requirement) {
 	for ar, requirement := range requirements {
@@ -0,0 +1,125 @@
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestHierarchy(t *testing.T) {
+	resources := []models.Resource{
+		{Name: "account"},
+		{Name: "subscription", Parents: []string{"account"}},
+		{Name: "network", Parents: []string{"account"}},
+		{Name: "vm", Parents: []string{"subscription", "network", "missing"}},
+	}
+	h, err := NewHierarchy(resources)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"subscription", "network", "missing", "account"}, h.Ancestors("vm"))
+	assert.DeepEqual(t, []string{"account"}, h.Ancestors("network"))
+	assert.DeepEqual(t, []string{}, h.Ancestors("account"))
+	assert.DeepEqual(t, []string{"network", "subscription"}, h.Children("account"))
+	assert.DeepEqual(t, []string{"network", "subscription", "vm"}, h.Descendants("account"))
+	assert.DeepEqual(t, []string{}, h.Descendants("vm"))
+}
+
+func TestHierarchyCycle(t *testing.T) {
+	testCase := []struct {
+		name          string
+		resources     []models.Resource
+		expectedCycle []string
+	}{
+		{
+			name:          "Self",
+			resources:     []models.Resource{{Name: "a", Parents: []string{"a"}}},
+			expectedCycle: []string{"a", "a"},
+		},
+		{
+			name: "Transitive",
+			resources: []models.Resource{
+				{Name: "leaf", Parents: []string{"a"}},
+				{Name: "a", Parents: []string{"b"}},
+				{Name: "b", Parents: []string{"c"}},
+				{Name: "c", Parents: []string{"a"}},
+			},
+			expectedCycle: []string{"a", "b", "c", "a"},
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewHierarchy(tc.resources)
+			var cycle *CycleError
+			assert.Assert(t, errors.As(err, &cycle))
+			assert.DeepEqual(t, tc.expectedCycle, cycle.Path)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	config := &models.Configuration{
+		Resources: []models.Resource{
+			{Name: "account", Classes: []string{"Platform"}},
+			{Name: "subscription", Classes: []string{"Platform"}, Parents: []string{"account"}},
+			{Name: "vm", Classes: []string{"VirtualMachine"}, Parents: []string{"subscription"}},
+			{Name: "other-vm", Classes: []string{"VirtualMachine"}},
+		},
+		Requirements: []models.Requirement{
+			{Name: "req", Code: "REQ-01", Version: "1.0.0", ApplicableResourceClasses: []string{"VirtualMachine"}},
+		},
+		Implementations: []models.Implementation{
+			{Name: "account-policy", RequirementRef: models.RequirementRef{Code: "REQ-01", Version: "1.0.0"}, ResourceRef: []string{"account"}},
+			{Name: "nearest", RequirementRef: models.RequirementRef{Code: "REQ-01", Version: "1.0.0"}, ResourceRef: []string{"account", "subscription"}},
+			{Name: "direct", RequirementRef: models.RequirementRef{Code: "REQ-01", Version: "1.0.0"}, ResourceRef: []string{"subscription", "vm"}},
+			{Name: "old", RequirementRef: models.RequirementRef{Code: "REQ-01", Version: "0.1.0"}, ResourceRef: []string{"vm"}},
+		},
+		Attestations: []models.Attestation{
+			{Name: "att", ImplementationRef: "account-policy"},
+		},
+	}
+	config, err := Resolve(config)
+	assert.NilError(t, err)
+
+	vm := config.Resources[2].Requirements["req"].Implementations
+	assert.Equal(t, 3, len(vm))
+	assert.Equal(t, "account", vm["account-policy"].InheritedFrom)
+	assert.Equal(t, "subscription", vm["nearest"].InheritedFrom)
+	assert.Equal(t, "", vm["direct"].InheritedFrom)
+	assert.Equal(t, 1, len(vm["account-policy"].Attestation))
+
+	assert.Equal(t, 0, len(config.Resources[3].Requirements["req"].Implementations))
+	assert.Equal(t, 0, len(config.Resources[0].Requirements))
+}
+
+func TestResolveCycle(t *testing.T) {
+	config := &models.Configuration{
+		Resources: []models.Resource{
+			{Name: "a", Parents: []string{"b"}},
+			{Name: "b", Parents: []string{"a"}},
+		},
+	}
+	_, err := Resolve(config)
+	assert.Error(t, err, "resource parents form a cycle: a -> b -> a")
+}
+
+func TestResolveUndefinedParent(t *testing.T) {
+	config := &models.Configuration{
+		Resources: []models.Resource{
+			{Name: "vm", Classes: []string{"VirtualMachine"}, Parents: []string{"external-account"}},
+		},
+		Requirements: []models.Requirement{
+			{Name: "req", Code: "REQ-01", Version: "1.0.0", ApplicableResourceClasses: []string{"VirtualMachine"}},
+		},
+		Implementations: []models.Implementation{
+			{Name: "account-policy", RequirementRef: models.RequirementRef{Code: "REQ-01", Version: "1.0.0"}, ResourceRef: []string{"external-account"}},
+		},
+	}
+	config, err := Resolve(config)
+	assert.NilError(t, err)
+
+	vm := config.Resources[0].Requirements["req"].Implementations
+	assert.Equal(t, 1, len(vm))
+	assert.Equal(t, "external-account", vm["account-policy"].InheritedFrom)
+}
@@ -0,0 +1,193 @@
+package html
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/results/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var tmpl = template.Must(template.New("report").Parse(reportTemplate))
+
+type HTMLSummary struct {
+}
+
+type report struct {
+	GeneratedAt string
+	Counts      counts
+	Resources   []resource
+}
+
+type counts struct {
+	Requirements            int
+	ImplementedRequirements int
+	Passed                  int
+	Failed                  int
+	Unknown                 int
+	NotRun                  int
+}
+
+type resource struct {
+	Name                 string
+	Type                 string
+	Compliant            bool
+	Children             int
+	CompliantChildren    int
+	Descendants          int
+	CompliantDescendants int
+	Counts               counts
+	Requirements         []requirement
+}
+
+type requirement struct {
+	Name                    string
+	Code                    string
+	Version                 string
+	Description             string
+	Implemented             bool
+	AttestedImplementations int
+	TotalImplementations    int
+	Implementations         []implementation
+}
+
+type implementation struct {
+	Name                 string
+	Class                string
+	InheritedFrom        string
+	Attested             bool
+	VerifiedAttestations int
+	TotalAttestations    int
+	Attestations         []attestation
+}
+
+type attestation struct {
+	Name    string
+	Type    string
+	Result  string
+	Status  string
+	Reason  string
+	Command string
+	RunAt   string
+	Logs    string
+	Err     string
+}
+
+func init() {
+	schema.Register("html", &HTMLSummary{})
+}
+
+// Summary writes a report with the pass/fail counts of every resource, along with a collapsed
+// drill-down into requirements, implementations and attestations, including their logs.
+// The report is a single page, so every mode writes the same one.
+func (t *HTMLSummary) Summary(w io.Writer, c *models.Configuration) {
+	err := write(w, c)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while rendering:%v", err)
+	}
+}
+
+func (t *HTMLSummary) Detailed(w io.Writer, c *models.Configuration) {
+	t.Summary(w, c)
+}
+
+func (t *HTMLSummary) All(w io.Writer, c *models.Configuration) {
+	t.Summary(w, c)
+}
+
+func write(w io.Writer, c *models.Configuration) error {
+	r := build(c)
+	r.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+	return tmpl.Execute(w, r)
+}
+
+func build(c *models.Configuration) report {
+	rep := report{}
+	for _, r := range c.Resources {
+		res := resource{
+			Name:                 r.Name,
+			Type:                 r.Type,
+			Compliant:            r.Compliant,
+			Children:             r.Children,
+			CompliantChildren:    r.CompliantChildren,
+			Descendants:          r.Descendants,
+			CompliantDescendants: r.CompliantDescendants,
+			Counts: counts{
+				Requirements:            len(r.Requirements),
+				ImplementedRequirements: r.ImplementedRequirements,
+			},
+		}
+		for _, reqKey := range utils.SortedKeys(r.Requirements) {
+			req := r.Requirements[reqKey]
+			rv := requirement{
+				Name:                    req.Requirement.Name,
+				Code:                    req.Requirement.Code,
+				Version:                 req.Requirement.Version,
+				Description:             req.Requirement.Description,
+				Implemented:             req.Implemented,
+				AttestedImplementations: req.AttestedImplementations,
+				TotalImplementations:    req.TotalImplementations,
+			}
+			for _, impKey := range utils.SortedKeys(req.Implementations) {
+				imp := req.Implementations[impKey]
+				iv := implementation{
+					Name:                 imp.Implementation.Name,
+					Class:                imp.Implementation.Class,
+					InheritedFrom:        imp.InheritedFrom,
+					Attested:             imp.Attested,
+					VerifiedAttestations: imp.VerifiedAttestations,
+					TotalAttestations:    imp.TotalAttestations,
+				}
+				for _, attKey := range utils.SortedKeys(imp.Attestation) {
+					att := imp.Attestation[attKey].Attestation
+					av := attestation{
+						Name:    att.Name,
+						Type:    att.Type,
+						Result:  att.Result.Result,
+						Reason:  att.Result.Reason,
+						Command: att.Result.Command,
+						Logs:    att.Result.Logs,
+						Err:     att.Result.Err,
+					}
+					if !att.Result.RunAt.IsZero() {
+						av.RunAt = att.Result.RunAt.UTC().Format(time.RFC3339)
+					}
+					switch att.Result.Result {
+					case "PASS":
+						av.Status = "pass"
+						res.Counts.Passed = res.Counts.Passed + 1
+					case "FAIL":
+						av.Status = "fail"
+						res.Counts.Failed = res.Counts.Failed + 1
+					case "":
+						av.Status = "notrun"
+						av.Result = "NOT RUN"
+						res.Counts.NotRun = res.Counts.NotRun + 1
+					default:
+						av.Status = "unknown"
+						res.Counts.Unknown = res.Counts.Unknown + 1
+					}
+					iv.Attestations = append(iv.Attestations, av)
+				}
+				rv.Implementations = append(rv.Implementations, iv)
+			}
+			res.Requirements = append(res.Requirements, rv)
+		}
+		rep.Counts.Requirements = rep.Counts.Requirements + res.Counts.Requirements
+		rep.Counts.ImplementedRequirements = rep.Counts.ImplementedRequirements + res.Counts.ImplementedRequirements
+		rep.Counts.Passed = rep.Counts.Passed + res.Counts.Passed
+		rep.Counts.Failed = rep.Counts.Failed + res.Counts.Failed
+		rep.Counts.Unknown = rep.Counts.Unknown + res.Counts.Unknown
+		rep.Counts.NotRun = rep.Counts.NotRun + res.Counts.NotRun
+		rep.Resources = append(rep.Resources, res)
+	}
+	return rep
+}
@@ -0,0 +1,81 @@
+package html
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func makeConfig() *models.Configuration {
+	req := &models.Requirement{Name: "VMs cannot have data disks", Code: "REQ-01", Version: "1.0.0", Description: "No data disks."}
+	imp := &models.Implementation{Name: "policy", Class: "Preventative"}
+	runAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &models.Configuration{
+		Resources: []models.Resource{
+			{
+				Name: "vm",
+				Type: "azure",
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {
+						Requirement: req,
+						Implementations: map[string]models.ImplementationBlock{
+							imp.Name: {
+								Implementation: imp,
+								Attestation: map[string]models.AttestationBlock{
+									"a-pass":    {Attestation: &models.Attestation{Name: "a-pass", Result: models.AttestationResult{Result: "PASS", RunAt: runAt}}},
+									"b-fail":    {Attestation: &models.Attestation{Name: "b-fail", Result: models.AttestationResult{Result: "FAIL", Reason: "Code failed!", Logs: "<script>alert(1)</script>", RunAt: runAt}}},
+									"c-unknown": {Attestation: &models.Attestation{Name: "c-unknown", Result: models.AttestationResult{Result: "UNKNOWN"}}},
+									"d-not-run": {Attestation: &models.Attestation{Name: "d-not-run"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r := build(makeConfig())
+	assert.Equal(t, 1, len(r.Resources))
+	assert.Equal(t, counts{Requirements: 1, Passed: 1, Failed: 1, Unknown: 1, NotRun: 1}, r.Counts)
+	assert.Equal(t, r.Counts, r.Resources[0].Counts)
+	atts := r.Resources[0].Requirements[0].Implementations[0].Attestations
+	assert.Equal(t, 4, len(atts))
+	assert.Equal(t, "pass", atts[0].Status)
+	assert.Equal(t, "2023-01-02T03:04:05Z", atts[0].RunAt)
+	assert.Equal(t, "fail", atts[1].Status)
+	assert.Equal(t, "unknown", atts[2].Status)
+	assert.Equal(t, "NOT RUN", atts[3].Result)
+}
+
+func TestWrite(t *testing.T) {
+	testCase := []struct {
+		name  string
+		write func(io.Writer, *models.Configuration)
+	}{
+		{name: "Summary", write: (&HTMLSummary{}).Summary},
+		{name: "Detailed", write: (&HTMLSummary{}).Detailed},
+		{name: "All", write: (&HTMLSummary{}).All},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			tc.write(&b, makeConfig())
+			out := b.String()
+			assert.Assert(t, strings.Contains(out, "<td>vm</td>"))
+			assert.Assert(t, !strings.Contains(out, "<script>"))
+			assert.Assert(t, !strings.Contains(out, "<link"))
+			assert.Assert(t, strings.Contains(out, "<details"))
+			assert.Assert(t, strings.Contains(out, "&lt;script&gt;alert(1)&lt;/script&gt;"))
+			assert.Assert(t, strings.Contains(out, "No data disks."))
+		})
+	}
+}
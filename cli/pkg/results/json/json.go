@@ -3,6 +3,7 @@ package json
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -16,12 +17,16 @@ type JSONSummary struct {
 func init() {
 	schema.Register("json", &JSONSummary{})
 }
-func (t *JSONSummary) Summary(c *models.Configuration) {
+func (t *JSONSummary) Summary(w io.Writer, c *models.Configuration) {
 	d := []struct {
 		Resource                string
 		Status                  string
 		TotalRequirements       int
 		ImplementedRequirements int
+		Children                int
+		CompliantChildren       int
+		Descendants             int
+		CompliantDescendants    int
 	}{}
 	for _, r := range c.Resources {
 		d = append(d, struct {
@@ -29,16 +34,20 @@ func (t *JSONSummary) Summary(c *models.Configuration) {
 			Status                  string
 			TotalRequirements       int
 			ImplementedRequirements int
-		}{r.Name, fmt.Sprint(r.Implemented), len(r.Requirements), r.ImplementedRequirements})
+			Children                int
+			CompliantChildren       int
+			Descendants             int
+			CompliantDescendants    int
+		}{r.Name, fmt.Sprint(r.Compliant), len(r.Requirements), r.ImplementedRequirements, r.Children, r.CompliantChildren, r.Descendants, r.CompliantDescendants})
 	}
-	w := json.NewEncoder(os.Stdout)
-	err := w.Encode(d)
+	enc := json.NewEncoder(w)
+	err := enc.Encode(d)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error happened encoding:%v", err)
 	}
 }
 
-func (t *JSONSummary) Detailed(c *models.Configuration) {
+func (t *JSONSummary) Detailed(w io.Writer, c *models.Configuration) {
 	d := []struct {
 		Resource       string
 		Implementation string
@@ -111,16 +120,16 @@ func (t *JSONSummary) Detailed(c *models.Configuration) {
 		}
 
 	}
-	w := json.NewEncoder(os.Stdout)
-	err := w.Encode(d)
+	enc := json.NewEncoder(w)
+	err := enc.Encode(d)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
 	}
 }
 
-func (t *JSONSummary) All(c *models.Configuration) {
-	w := json.NewEncoder(os.Stdout)
-	err := w.Encode(c.Resources)
+func (t *JSONSummary) All(w io.Writer, c *models.Configuration) {
+	enc := json.NewEncoder(w)
+	err := enc.Encode(c.Resources)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
 	}
@@ -0,0 +1,166 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/results/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+type JUnitSummary struct {
+}
+
+type testSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Suites   []testSuite `xml:"testsuite"`
+}
+
+type testSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Errors    int        `xml:"errors,attr"`
+	Skipped   int        `xml:"skipped,attr"`
+	Timestamp string     `xml:"timestamp,attr,omitempty"`
+	Cases     []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *message `xml:"failure,omitempty"`
+	Error     *message `xml:"error,omitempty"`
+	Skipped   *message `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+type message struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+func init() {
+	schema.Register("junit", &JUnitSummary{})
+}
+
+// Summary reports every attestation as a testcase, with its logs in system-out.
+// CI systems only read the one JUnit report, so every mode writes the same one.
+func (t *JUnitSummary) Summary(w io.Writer, c *models.Configuration) {
+	err := write(w, c)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
+	}
+}
+
+func (t *JUnitSummary) Detailed(w io.Writer, c *models.Configuration) {
+	t.Summary(w, c)
+}
+
+func (t *JUnitSummary) All(w io.Writer, c *models.Configuration) {
+	t.Summary(w, c)
+}
+
+func write(w io.Writer, c *models.Configuration) error {
+	report := build(c)
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(report)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func build(c *models.Configuration) testSuites {
+	report := testSuites{Name: "argus"}
+	for _, r := range c.Resources {
+		suite := testSuite{Name: r.Name}
+		var last time.Time
+		for _, reqKey := range utils.SortedKeys(r.Requirements) {
+			req := r.Requirements[reqKey]
+			if len(req.Implementations) == 0 {
+				tc := testCase{
+					Name:      req.Requirement.Name,
+					ClassName: req.Requirement.Code,
+				}
+				if !req.Implemented {
+					tc.Failure = &message{Message: "no implementations found for requirement", Type: "FAIL"}
+				}
+				suite.add(tc)
+				continue
+			}
+			for _, impKey := range utils.SortedKeys(req.Implementations) {
+				imp := req.Implementations[impKey]
+				className := fmt.Sprintf("%v.%v", req.Requirement.Code, imp.Implementation.Name)
+				if len(imp.Attestation) == 0 {
+					suite.add(testCase{
+						Name:      imp.Implementation.Name,
+						ClassName: className,
+						Skipped:   &message{Message: "no attestations found for implementation"},
+					})
+					continue
+				}
+				for _, attKey := range utils.SortedKeys(imp.Attestation) {
+					att := imp.Attestation[attKey].Attestation
+					res := att.Result
+					tc := testCase{
+						Name:      att.Name,
+						ClassName: className,
+					}
+					switch res.Result {
+					case "PASS":
+					case "FAIL":
+						tc.Failure = &message{Message: res.Reason, Type: res.Result, Body: res.Reason}
+					case "":
+						tc.Skipped = &message{Message: "attestation has not been run"}
+					default:
+						tc.Error = &message{Message: res.Reason, Type: res.Result, Body: res.Err}
+					}
+					tc.SystemOut = res.Logs
+					if res.RunAt.After(last) {
+						last = res.RunAt
+					}
+					suite.add(tc)
+				}
+			}
+		}
+		if !last.IsZero() {
+			suite.Timestamp = last.UTC().Format(time.RFC3339)
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+func (s *testSuite) add(tc testCase) {
+	s.Tests = s.Tests + 1
+	switch {
+	case tc.Failure != nil:
+		s.Failures = s.Failures + 1
+	case tc.Error != nil:
+		s.Errors = s.Errors + 1
+	case tc.Skipped != nil:
+		s.Skipped = s.Skipped + 1
+	}
+	s.Cases = append(s.Cases, tc)
+}
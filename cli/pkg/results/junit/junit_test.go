@@ -0,0 +1,90 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func makeConfig() *models.Configuration {
+	req := &models.Requirement{Name: "VMs cannot have data disks", Code: "REQ-01", Version: "1.0.0", RequiredImplementationClasses: []string{"Preventative"}}
+	imp := &models.Implementation{Name: "policy", Class: "Preventative"}
+	runAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &models.Configuration{
+		Resources: []models.Resource{
+			{
+				Name: "vm",
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {
+						Requirement: req,
+						Implementations: map[string]models.ImplementationBlock{
+							imp.Name: {
+								Implementation: imp,
+								Attestation: map[string]models.AttestationBlock{
+									"a-pass":    {Attestation: &models.Attestation{Name: "a-pass", Result: models.AttestationResult{Result: "PASS", Logs: "all good", RunAt: runAt}}},
+									"b-fail":    {Attestation: &models.Attestation{Name: "b-fail", Result: models.AttestationResult{Result: "FAIL", Reason: "Code failed!", Logs: "$ false:\n", RunAt: runAt}}},
+									"c-unknown": {Attestation: &models.Attestation{Name: "c-unknown", Result: models.AttestationResult{Result: "UNKNOWN", Reason: "timed out", Err: "context deadline exceeded"}}},
+									"d-not-run": {Attestation: &models.Attestation{Name: "d-not-run"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "unimplemented",
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {Requirement: req},
+				},
+			},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	testCase := []struct {
+		name  string
+		write func(io.Writer, *models.Configuration)
+	}{
+		{name: "Summary", write: (&JUnitSummary{}).Summary},
+		{name: "Detailed", write: (&JUnitSummary{}).Detailed},
+		{name: "All", write: (&JUnitSummary{}).All},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			tc.write(&b, makeConfig())
+			report := testSuites{}
+			err := xml.Unmarshal(b.Bytes(), &report)
+			assert.NilError(t, err)
+			assert.Equal(t, 5, report.Tests)
+			assert.Equal(t, 2, report.Failures)
+			assert.Equal(t, 1, report.Errors)
+			assert.Equal(t, 1, report.Skipped)
+			assert.Equal(t, 2, len(report.Suites))
+
+			vm := report.Suites[0]
+			assert.Equal(t, "vm", vm.Name)
+			assert.Equal(t, "2023-01-02T03:04:05Z", vm.Timestamp)
+			assert.Equal(t, 4, len(vm.Cases))
+			assert.Equal(t, "a-pass", vm.Cases[0].Name)
+			assert.Equal(t, "REQ-01.policy", vm.Cases[0].ClassName)
+			assert.Assert(t, vm.Cases[0].Failure == nil)
+			assert.Equal(t, "Code failed!", vm.Cases[1].Failure.Message)
+			assert.Equal(t, "timed out", vm.Cases[2].Error.Message)
+			assert.Equal(t, "context deadline exceeded", vm.Cases[2].Error.Body)
+			assert.Assert(t, vm.Cases[3].Skipped != nil)
+			assert.Equal(t, "$ false:\n", vm.Cases[1].SystemOut)
+
+			unimplemented := report.Suites[1]
+			assert.Equal(t, 1, unimplemented.Failures)
+			assert.Equal(t, "no implementations found for requirement", unimplemented.Cases[0].Failure.Message)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package oscal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	model "github.com/ContainerSolutions/argus/cli/pkg/oscal"
+	"github.com/ContainerSolutions/argus/cli/pkg/results/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+type OSCALSummary struct {
+}
+
+func init() {
+	schema.Register("oscal", &OSCALSummary{})
+}
+
+// Summary writes the results as OSCAL assessment-results, without logs.
+func (t *OSCALSummary) Summary(w io.Writer, c *models.Configuration) {
+	err := write(w, c, false, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
+	}
+}
+
+// Detailed writes the results as OSCAL assessment-results, with the logs of every attestation as evidence.
+func (t *OSCALSummary) Detailed(w io.Writer, c *models.Configuration) {
+	err := write(w, c, true, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
+	}
+}
+
+func (t *OSCALSummary) All(w io.Writer, c *models.Configuration) {
+	t.Detailed(w, c)
+}
+
+func write(w io.Writer, c *models.Configuration, withLogs bool, now time.Time) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(build(c, withLogs, now))
+}
+
+// build maps every resource to a component, every attestation run to an observation of its resource,
+// and every requirement to a finding, satisfied when all the resources it applies to implement it.
+// argus has no assessment plans, so import-ap points to the document itself.
+func build(c *models.Configuration, withLogs bool, now time.Time) model.AssessmentResultsDocument {
+	components := []model.SystemComponent{}
+	observations := []model.Observation{}
+	findings := map[string]*model.Finding{}
+	implemented := map[string]int{}
+	total := map[string]int{}
+	var start, end time.Time
+	for _, r := range c.Resources {
+		subject := model.SubjectReference{SubjectUUID: model.UUID("resource/" + r.Name), Type: "component", Title: r.Name}
+		components = append(components, component(r, subject.SubjectUUID))
+		for _, reqKey := range utils.SortedKeys(r.Requirements) {
+			req := r.Requirements[reqKey]
+			key := requirementKey(req.Requirement)
+			finding, ok := findings[key]
+			if !ok {
+				finding = newFinding(req.Requirement)
+				findings[key] = finding
+			}
+			total[key] = total[key] + 1
+			if req.Implemented {
+				implemented[key] = implemented[key] + 1
+			}
+			for _, impKey := range utils.SortedKeys(req.Implementations) {
+				imp := req.Implementations[impKey]
+				for _, attKey := range utils.SortedKeys(imp.Attestation) {
+					att := imp.Attestation[attKey].Attestation
+					if att.Result.RunAt.IsZero() {
+						continue
+					}
+					if start.IsZero() || att.Result.RunAt.Before(start) {
+						start = att.Result.RunAt
+					}
+					if att.Result.RunAt.After(end) {
+						end = att.Result.RunAt
+					}
+					obs := observation(r, req.Requirement, imp.Implementation, att, subject, withLogs)
+					observations = append(observations, obs)
+					finding.RelatedObservations = append(finding.RelatedObservations, model.RelatedObservation{ObservationUUID: obs.UUID})
+				}
+			}
+		}
+	}
+	result := model.Result{
+		UUID:             model.UUID("result/" + timestamp(now)),
+		Title:            "argus attestation results",
+		Description:      fmt.Sprintf("Attestation results of %v resources against %v requirements.", len(c.Resources), len(findings)),
+		Start:            timestamp(now),
+		ReviewedControls: model.ReviewedControls{ControlSelections: []model.ControlSelection{{IncludeAll: &struct{}{}}}},
+	}
+	if !start.IsZero() {
+		result.Start = timestamp(start)
+		result.End = timestamp(end)
+	}
+	if len(components) > 0 {
+		result.LocalDefinitions = &model.LocalDefinitions{Components: components}
+	}
+	if len(observations) > 0 {
+		result.Observations = observations
+	}
+	if len(findings) > 0 {
+		selection := model.ControlSelection{}
+		for _, key := range utils.SortedKeys(findings) {
+			finding := findings[key]
+			finding.Target.Status = status(implemented[key], total[key])
+			result.Findings = append(result.Findings, *finding)
+			selection.IncludeControls = append(selection.IncludeControls, model.SelectControlID{ControlID: finding.Target.TargetID})
+		}
+		result.ReviewedControls.ControlSelections = []model.ControlSelection{selection}
+	}
+	return model.AssessmentResultsDocument{
+		AssessmentResults: model.AssessmentResults{
+			UUID: model.UUID("assessment-results/" + timestamp(now)),
+			Metadata: model.Metadata{
+				Title:        "argus assessment results",
+				LastModified: timestamp(now),
+				Version:      timestamp(now),
+				OSCALVersion: model.Version,
+			},
+			ImportAP: model.ImportAP{Href: "#"},
+			Results:  []model.Result{result},
+		},
+	}
+}
+
+func component(r models.Resource, uuid string) model.SystemComponent {
+	componentType := strings.Join(strings.Fields(r.Type), " ")
+	if componentType == "" {
+		componentType = "other"
+	}
+	res := model.SystemComponent{
+		UUID:        uuid,
+		Type:        componentType,
+		Title:       r.Name,
+		Description: fmt.Sprintf("argus resource '%v'", r.Name),
+		Status:      model.ComponentStatus{State: "operational"},
+	}
+	for _, class := range r.Classes {
+		res.Props = append(res.Props, model.Prop("class", class))
+	}
+	for _, parent := range r.Parents {
+		res.Props = append(res.Props, model.Prop("parent", parent))
+	}
+	return res
+}
+
+func newFinding(req *models.Requirement) *model.Finding {
+	description := req.Description
+	if description == "" {
+		description = req.Name
+	}
+	return &model.Finding{
+		UUID:        model.UUID("finding/" + requirementKey(req)),
+		Title:       req.Name,
+		Description: description,
+		Props: []model.Property{
+			model.Prop("requirement-code", req.Code),
+			model.Prop("requirement-version", req.Version),
+		},
+		Target: model.FindingTarget{
+			Type:     "objective-id",
+			TargetID: model.Token(req.Code),
+		},
+	}
+}
+
+func observation(r models.Resource, req *models.Requirement, imp *models.Implementation, att *models.Attestation, subject model.SubjectReference, withLogs bool) model.Observation {
+	description := fmt.Sprintf("Attestation '%v' of implementation '%v' of requirement '%v' on resource '%v': %v",
+		att.Name, imp.Name, req.Code, r.Name, att.Result.Result)
+	if att.Result.Reason != "" {
+		description = fmt.Sprintf("%v (%v)", description, att.Result.Reason)
+	}
+	evidence := model.RelevantEvidence{Description: fmt.Sprintf("Ran %v attestation '%v'", att.Type, att.Name)}
+	if att.Result.Command != "" {
+		evidence.Description = fmt.Sprintf("Ran `%v`", att.Result.Command)
+	}
+	if withLogs {
+		evidence.Remarks = att.Result.Logs
+	}
+	return model.Observation{
+		UUID:        model.UUID(fmt.Sprintf("observation/%v/%v/%v/%v/%v", r.Name, requirementKey(req), imp.Name, att.Name, timestamp(att.Result.RunAt))),
+		Title:       att.Name,
+		Description: description,
+		Props: []model.Property{
+			model.Prop("result", att.Result.Result),
+			model.Prop("attestation-type", att.Type),
+			model.Prop("implementation", imp.Name),
+		},
+		Methods:          []string{"TEST"},
+		Subjects:         []model.SubjectReference{subject},
+		RelevantEvidence: []model.RelevantEvidence{evidence},
+		Collected:        timestamp(att.Result.RunAt),
+	}
+}
+
+func status(implemented, total int) model.ObjectiveStatus {
+	res := model.ObjectiveStatus{
+		State:   "satisfied",
+		Reason:  "pass",
+		Remarks: fmt.Sprintf("%v/%v resources implement the requirement", implemented, total),
+	}
+	if implemented < total {
+		res.State = "not-satisfied"
+		res.Reason = "fail"
+	}
+	return res
+}
+
+func requirementKey(req *models.Requirement) string {
+	return req.Code + "/" + req.Version
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
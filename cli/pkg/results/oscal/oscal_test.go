@@ -0,0 +1,134 @@
+package oscal
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	model "github.com/ContainerSolutions/argus/cli/pkg/oscal"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gotest.tools/v3/assert"
+)
+
+// testdata/oscal_assessment-results_schema.json is the assessment-results part of the OSCAL 1.1.2 complete JSON schema.
+const schemaPath = "testdata/oscal_assessment-results_schema.json"
+
+var now = time.Date(2023, 1, 2, 4, 0, 0, 0, time.UTC)
+
+func makeConfig() *models.Configuration {
+	req := models.Requirement{Name: "VMs cannot have data disks", Code: "REQ-01", Version: "1.0.0", Class: "Security", Category: "Internal", Description: "No data disks."}
+	cis := models.Requirement{Name: "Audit logs", Code: "1.2.1", Version: "1.8"}
+	imp := &models.Implementation{Name: "policy", Class: "Preventative"}
+	runAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &models.Configuration{
+		Requirements: []models.Requirement{req, cis},
+		Resources: []models.Resource{
+			{
+				Name:    "vm",
+				Type:    "VirtualMachine",
+				Classes: []string{"VirtualMachine"},
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {
+						Requirement: &req,
+						Implemented: true,
+						Implementations: map[string]models.ImplementationBlock{
+							imp.Name: {
+								Implementation: imp,
+								Attestation: map[string]models.AttestationBlock{
+									"a-pass":    {Attestation: &models.Attestation{Name: "a-pass", Type: "command", Result: models.AttestationResult{Command: "true", Result: "PASS", Logs: "$ true:\n", RunAt: runAt}}},
+									"b-unknown": {Attestation: &models.Attestation{Name: "b-unknown", Type: "command", Result: models.AttestationResult{Result: "UNKNOWN"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:    "db",
+				Parents: []string{"vm"},
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {
+						Requirement: &req,
+						Implementations: map[string]models.ImplementationBlock{
+							imp.Name: {
+								Implementation: imp,
+								Attestation: map[string]models.AttestationBlock{
+									"c-fail": {Attestation: &models.Attestation{Name: "c-fail", Type: "http", Result: models.AttestationResult{Result: "FAIL", Reason: "Code failed!", Logs: "503\n", RunAt: runAt.Add(time.Minute)}}},
+								},
+							},
+						},
+					},
+					cis.Name: {Requirement: &cis, Implemented: true},
+				},
+			},
+		},
+	}
+}
+
+func validate(t *testing.T, data []byte) {
+	t.Helper()
+	compiler := jsonschema.NewCompiler()
+	sch, err := compiler.Compile(schemaPath)
+	assert.NilError(t, err)
+	var doc interface{}
+	assert.NilError(t, json.Unmarshal(data, &doc))
+	assert.NilError(t, sch.Validate(doc))
+}
+
+func TestWrite(t *testing.T) {
+	testCase := []struct {
+		name         string
+		withLogs     bool
+		expectedLogs string
+	}{
+		{name: "Summary", withLogs: false, expectedLogs: ""},
+		{name: "Detailed", withLogs: true, expectedLogs: "503\n"},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := write(&b, makeConfig(), tc.withLogs, now)
+			assert.NilError(t, err)
+			validate(t, b.Bytes())
+			doc := model.AssessmentResultsDocument{}
+			assert.NilError(t, json.Unmarshal(b.Bytes(), &doc))
+			result := doc.AssessmentResults.Results[0]
+			assert.Equal(t, "2023-01-02T03:04:05Z", result.Start)
+			assert.Equal(t, "2023-01-02T03:05:05Z", result.End)
+
+			assert.Equal(t, 2, len(result.LocalDefinitions.Components))
+			assert.Equal(t, "vm", result.LocalDefinitions.Components[0].Title)
+			assert.Equal(t, "other", result.LocalDefinitions.Components[1].Type)
+
+			// Attestations that never ran are no observation.
+			assert.Equal(t, 2, len(result.Observations))
+			pass, fail := result.Observations[0], result.Observations[1]
+			assert.Equal(t, "Ran `true`", pass.RelevantEvidence[0].Description)
+			assert.Equal(t, result.LocalDefinitions.Components[0].UUID, pass.Subjects[0].SubjectUUID)
+			assert.Equal(t, "Ran http attestation 'c-fail'", fail.RelevantEvidence[0].Description)
+			assert.Equal(t, tc.expectedLogs, fail.RelevantEvidence[0].Remarks)
+			assert.Equal(t, "2023-01-02T03:05:05Z", fail.Collected)
+			assert.Equal(t, result.LocalDefinitions.Components[1].UUID, fail.Subjects[0].SubjectUUID)
+
+			assert.Equal(t, 2, len(result.Findings))
+			cis, req := result.Findings[0], result.Findings[1]
+			assert.Equal(t, "_1.2.1", cis.Target.TargetID)
+			assert.Equal(t, "satisfied", cis.Target.Status.State)
+			assert.Equal(t, "REQ-01", req.Target.TargetID)
+			assert.Equal(t, "not-satisfied", req.Target.Status.State)
+			assert.Equal(t, "1/2 resources implement the requirement", req.Target.Status.Remarks)
+			assert.DeepEqual(t, []model.RelatedObservation{{ObservationUUID: pass.UUID}, {ObservationUUID: fail.UUID}}, req.RelatedObservations)
+			assert.DeepEqual(t, []model.SelectControlID{{ControlID: "_1.2.1"}, {ControlID: "REQ-01"}}, result.ReviewedControls.ControlSelections[0].IncludeControls)
+		})
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	var b bytes.Buffer
+	err := write(&b, &models.Configuration{}, true, now)
+	assert.NilError(t, err)
+	validate(t, b.Bytes())
+}
@@ -2,36 +2,41 @@ package results
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	_ "github.com/ContainerSolutions/argus/cli/pkg/results/html"
 	_ "github.com/ContainerSolutions/argus/cli/pkg/results/json"
+	_ "github.com/ContainerSolutions/argus/cli/pkg/results/junit"
+	_ "github.com/ContainerSolutions/argus/cli/pkg/results/oscal"
+	_ "github.com/ContainerSolutions/argus/cli/pkg/results/sarif"
 	"github.com/ContainerSolutions/argus/cli/pkg/results/schema"
 	_ "github.com/ContainerSolutions/argus/cli/pkg/results/tsv"
 )
 
-func Summary(config *models.Configuration, format string) error {
+func Summary(w io.Writer, config *models.Configuration, format string) error {
 	sum, ok := schema.Registry[format]
 	if !ok {
 		return fmt.Errorf("summary format %v is not supported", format)
 	}
-	sum.Summary(config)
+	sum.Summary(w, config)
 	return nil
 }
 
-func Detailed(config *models.Configuration, format string) error {
+func Detailed(w io.Writer, config *models.Configuration, format string) error {
 	sum, ok := schema.Registry[format]
 	if !ok {
 		return fmt.Errorf("summary format %v is not supported", format)
 	}
-	sum.Detailed(config)
+	sum.Detailed(w, config)
 	return nil
 }
 
-func All(config *models.Configuration, format string) error {
+func All(w io.Writer, config *models.Configuration, format string) error {
 	sum, ok := schema.Registry[format]
 	if !ok {
 		return fmt.Errorf("summary format %v is not supported", format)
 	}
-	sum.All(config)
+	sum.All(w, config)
 	return nil
 }
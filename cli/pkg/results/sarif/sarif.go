@@ -0,0 +1,231 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/results/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	informationURI = "https://github.com/ContainerSolutions/argus"
+)
+
+type SARIFSummary struct {
+}
+
+type log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool    tool     `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Rules          []rule `json:"rules"`
+}
+
+type rule struct {
+	ID               string         `json:"id"`
+	ShortDescription *text          `json:"shortDescription,omitempty"`
+	FullDescription  *text          `json:"fullDescription,omitempty"`
+	Properties       ruleProperties `json:"properties"`
+}
+
+type ruleProperties struct {
+	Version  string   `json:"version,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Class    string   `json:"class,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+type text struct {
+	Text string `json:"text"`
+}
+
+type result struct {
+	RuleID     string           `json:"ruleId"`
+	RuleIndex  int              `json:"ruleIndex"`
+	Level      string           `json:"level"`
+	Message    text             `json:"message"`
+	Locations  []location       `json:"locations"`
+	Properties resultProperties `json:"properties"`
+}
+
+type resultProperties struct {
+	Requirement    string `json:"requirement"`
+	Implementation string `json:"implementation"`
+	Attestation    string `json:"attestation"`
+	RunAt          string `json:"runAt,omitempty"`
+	Logs           string `json:"logs,omitempty"`
+}
+
+type location struct {
+	LogicalLocations []logicalLocation `json:"logicalLocations"`
+}
+
+type logicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+func init() {
+	schema.Register("sarif", &SARIFSummary{})
+}
+
+// Summary reports every failing attestation as a SARIF result, without logs.
+func (t *SARIFSummary) Summary(w io.Writer, c *models.Configuration) {
+	err := write(w, c, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
+	}
+}
+
+// Detailed reports every failing attestation as a SARIF result, with its logs.
+func (t *SARIFSummary) Detailed(w io.Writer, c *models.Configuration) {
+	err := write(w, c, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while encoding:%v", err)
+	}
+}
+
+func (t *SARIFSummary) All(w io.Writer, c *models.Configuration) {
+	t.Detailed(w, c)
+}
+
+func write(w io.Writer, c *models.Configuration, withLogs bool) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(build(c, withLogs))
+}
+
+func build(c *models.Configuration, withLogs bool) log {
+	rules, index := buildRules(c)
+	results := []result{}
+	for _, r := range c.Resources {
+		for _, reqKey := range utils.SortedKeys(r.Requirements) {
+			req := r.Requirements[reqKey]
+			for _, impKey := range utils.SortedKeys(req.Implementations) {
+				imp := req.Implementations[impKey]
+				for _, attKey := range utils.SortedKeys(imp.Attestation) {
+					att := imp.Attestation[attKey].Attestation
+					if att.Result.Result != "FAIL" {
+						continue
+					}
+					msg := att.Result.Reason
+					if msg == "" {
+						msg = fmt.Sprintf("attestation '%v' failed", att.Name)
+					}
+					res := result{
+						RuleID:    ruleID(req.Requirement),
+						RuleIndex: index[ruleID(req.Requirement)],
+						Level:     "error",
+						Message:   text{Text: msg},
+						Locations: []location{
+							{LogicalLocations: []logicalLocation{{Name: r.Name, Kind: "resource"}}},
+						},
+						Properties: resultProperties{
+							Requirement:    req.Requirement.Name,
+							Implementation: imp.Implementation.Name,
+							Attestation:    att.Name,
+						},
+					}
+					if !att.Result.RunAt.IsZero() {
+						res.Properties.RunAt = att.Result.RunAt.UTC().Format(time.RFC3339)
+					}
+					if withLogs {
+						res.Properties.Logs = att.Result.Logs
+					}
+					results = append(results, res)
+				}
+			}
+		}
+	}
+	return log{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []run{
+			{
+				Tool: tool{Driver: driver{
+					Name:           "argus",
+					InformationURI: informationURI,
+					Rules:          rules,
+				}},
+				Results: results,
+			},
+		},
+	}
+}
+
+// ruleID identifies the rule of a requirement by its code and version, as every version of a requirement
+// may describe it differently.
+func ruleID(req *models.Requirement) string {
+	if req.Version == "" {
+		return req.Code
+	}
+	return req.Code + "@" + req.Version
+}
+
+// buildRules creates one rule per requirement code and version, sorted by rule id, and returns
+// the index of every rule in the resulting slice.
+func buildRules(c *models.Configuration) ([]rule, map[string]int) {
+	requirements := map[string]*models.Requirement{}
+	for i := range c.Requirements {
+		req := &c.Requirements[i]
+		if _, ok := requirements[ruleID(req)]; !ok {
+			requirements[ruleID(req)] = req
+		}
+	}
+	// Requirements only referenced through resources still need a rule.
+	for _, r := range c.Resources {
+		for _, req := range r.Requirements {
+			if _, ok := requirements[ruleID(req.Requirement)]; !ok {
+				requirements[ruleID(req.Requirement)] = req.Requirement
+			}
+		}
+	}
+	rules := []rule{}
+	index := map[string]int{}
+	for _, id := range utils.SortedKeys(requirements) {
+		req := requirements[id]
+		ru := rule{
+			ID: id,
+			Properties: ruleProperties{
+				Version:  req.Version,
+				Category: req.Category,
+				Class:    req.Class,
+			},
+		}
+		if req.Name != "" {
+			ru.ShortDescription = &text{Text: req.Name}
+		}
+		if req.Description != "" {
+			ru.FullDescription = &text{Text: req.Description}
+		}
+		for _, tag := range []string{req.Class, req.Category} {
+			if tag != "" {
+				ru.Properties.Tags = append(ru.Properties.Tags, tag)
+			}
+		}
+		index[id] = len(rules)
+		rules = append(rules, ru)
+	}
+	return rules, index
+}
@@ -0,0 +1,129 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func makeConfig() *models.Configuration {
+	req := models.Requirement{Name: "VMs cannot have data disks", Code: "REQ-01", Version: "1.0.0", Class: "Security", Category: "Internal", Description: "No data disks."}
+	other := models.Requirement{Name: "Unused", Code: "REQ-00", Version: "2.0.0"}
+	imp := &models.Implementation{Name: "policy", Class: "Preventative"}
+	runAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &models.Configuration{
+		Requirements: []models.Requirement{req, other},
+		Resources: []models.Resource{
+			{
+				Name: "vm",
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {
+						Requirement: &req,
+						Implementations: map[string]models.ImplementationBlock{
+							imp.Name: {
+								Implementation: imp,
+								Attestation: map[string]models.AttestationBlock{
+									"a-pass":    {Attestation: &models.Attestation{Name: "a-pass", Result: models.AttestationResult{Result: "PASS", RunAt: runAt}}},
+									"b-fail":    {Attestation: &models.Attestation{Name: "b-fail", Result: models.AttestationResult{Result: "FAIL", Reason: "Code failed!", Logs: "$ false:\n", RunAt: runAt}}},
+									"c-unknown": {Attestation: &models.Attestation{Name: "c-unknown", Result: models.AttestationResult{Result: "UNKNOWN", Reason: "timed out"}}},
+									"d-fail":    {Attestation: &models.Attestation{Name: "d-fail", Result: models.AttestationResult{Result: "FAIL"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	testCase := []struct {
+		name         string
+		withLogs     bool
+		expectedLogs string
+	}{
+		{name: "Summary", withLogs: false, expectedLogs: ""},
+		{name: "Detailed", withLogs: true, expectedLogs: "$ false:\n"},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := write(&b, makeConfig(), tc.withLogs)
+			assert.NilError(t, err)
+			report := log{}
+			err = json.Unmarshal(b.Bytes(), &report)
+			assert.NilError(t, err)
+			assert.Equal(t, "2.1.0", report.Version)
+			assert.Equal(t, 1, len(report.Runs))
+
+			rules := report.Runs[0].Tool.Driver.Rules
+			assert.Equal(t, 2, len(rules))
+			assert.Equal(t, "REQ-00@2.0.0", rules[0].ID)
+			assert.Assert(t, rules[0].FullDescription == nil)
+			assert.Equal(t, "REQ-01@1.0.0", rules[1].ID)
+			assert.Equal(t, "VMs cannot have data disks", rules[1].ShortDescription.Text)
+			assert.Equal(t, "No data disks.", rules[1].FullDescription.Text)
+			assert.Equal(t, "1.0.0", rules[1].Properties.Version)
+			assert.Equal(t, "Internal", rules[1].Properties.Category)
+
+			results := report.Runs[0].Results
+			assert.Equal(t, 2, len(results))
+			assert.Equal(t, "REQ-01@1.0.0", results[0].RuleID)
+			assert.Equal(t, 1, results[0].RuleIndex)
+			assert.Equal(t, "error", results[0].Level)
+			assert.Equal(t, "Code failed!", results[0].Message.Text)
+			assert.Equal(t, "vm", results[0].Locations[0].LogicalLocations[0].Name)
+			assert.Equal(t, "b-fail", results[0].Properties.Attestation)
+			assert.Equal(t, "2023-01-02T03:04:05Z", results[0].Properties.RunAt)
+			assert.Equal(t, tc.expectedLogs, results[0].Properties.Logs)
+			assert.Equal(t, "attestation 'd-fail' failed", results[1].Message.Text)
+		})
+	}
+}
+
+func TestWriteVersions(t *testing.T) {
+	c := makeConfig()
+	// The resource requires a newer version of REQ-01, which fails, on top of the first one.
+	newer := models.Requirement{Name: "VMs cannot have any data disk", Code: "REQ-01", Version: "2.0.0", Description: "No data disks at all."}
+	c.Requirements = append(c.Requirements, newer)
+	c.Resources[0].Requirements[newer.Name] = models.RequirementBlock{
+		Requirement: &newer,
+		Implementations: map[string]models.ImplementationBlock{
+			"policy": {
+				Implementation: &models.Implementation{Name: "policy"},
+				Attestation: map[string]models.AttestationBlock{
+					"fail": {Attestation: &models.Attestation{Name: "fail", Result: models.AttestationResult{Result: "FAIL"}}},
+				},
+			},
+		},
+	}
+	var b bytes.Buffer
+	err := write(&b, c, false)
+	assert.NilError(t, err)
+	report := log{}
+	err = json.Unmarshal(b.Bytes(), &report)
+	assert.NilError(t, err)
+
+	rules := report.Runs[0].Tool.Driver.Rules
+	assert.Equal(t, 3, len(rules))
+	assert.Equal(t, "REQ-01@1.0.0", rules[1].ID)
+	assert.Equal(t, "No data disks.", rules[1].FullDescription.Text)
+	assert.Equal(t, "REQ-01@2.0.0", rules[2].ID)
+	assert.Equal(t, "No data disks at all.", rules[2].FullDescription.Text)
+	assert.Equal(t, "2.0.0", rules[2].Properties.Version)
+
+	results := report.Runs[0].Results
+	assert.Equal(t, 3, len(results))
+	for _, res := range results {
+		assert.Equal(t, res.RuleID, rules[res.RuleIndex].ID)
+	}
+	assert.Equal(t, "REQ-01@2.0.0", results[0].RuleID)
+	assert.Equal(t, "VMs cannot have any data disk", results[0].Properties.Requirement)
+	assert.Equal(t, "REQ-01@1.0.0", results[1].RuleID)
+}
@@ -1,11 +1,15 @@
 package schema
 
-import "github.com/ContainerSolutions/argus/cli/pkg/models"
+import (
+	"io"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+)
 
 type ResultDriver interface {
-	Summary(c *models.Configuration)
-	Detailed(c *models.Configuration)
-	All(c *models.Configuration)
+	Summary(w io.Writer, c *models.Configuration)
+	Detailed(w io.Writer, c *models.Configuration)
+	All(w io.Writer, c *models.Configuration)
 }
 
 var Registry = make(map[string]ResultDriver)
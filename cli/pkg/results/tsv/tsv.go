@@ -2,6 +2,7 @@ package tsv
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
@@ -15,33 +16,39 @@ type TSVSummary struct {
 func init() {
 	schema.Register("tsv", &TSVSummary{})
 }
-func (t *TSVSummary) Summary(c *models.Configuration) {
-	w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
+func (t *TSVSummary) Summary(w io.Writer, c *models.Configuration) {
+	tw := tabwriter.NewWriter(w, 10, 4, 2, ' ', 0)
 	var line string
-	line = "Resource\tStatus\tTotal Requirements\tImplemented Requirements\n"
-	_, err := w.Write([]byte(line))
+	line = "Resource\tStatus\tTotal Requirements\tImplemented Requirements\tChildren\tCompliant Children\tDescendants\tCompliant Descendants\n"
+	_, err := tw.Write([]byte(line))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 	}
 	for _, r := range c.Resources {
-		line = fmt.Sprintf("%v\t%v\t%v\t%v\t\n", r.Name, r.Implemented, len(r.Requirements), r.ImplementedRequirements)
-		_, err := w.Write([]byte(line))
+		line = fmt.Sprintf("%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", r.Name, r.Compliant, len(r.Requirements), r.ImplementedRequirements, r.Children, r.CompliantChildren, r.Descendants, r.CompliantDescendants)
+		_, err := tw.Write([]byte(line))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 		}
 	}
-	w.Flush()
+	tw.Flush()
 }
 
-func (t *TSVSummary) All(c *models.Configuration) {
-
+// All prints the summary table followed by the detailed table.
+func (t *TSVSummary) All(w io.Writer, c *models.Configuration) {
+	t.Summary(w, c)
+	_, err := io.WriteString(w, "\n")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
+	}
+	t.Detailed(w, c)
 }
 
-func (t *TSVSummary) Detailed(c *models.Configuration) {
-	w := tabwriter.NewWriter(os.Stdout, 10, 4, 2, ' ', 0)
+func (t *TSVSummary) Detailed(w io.Writer, c *models.Configuration) {
+	tw := tabwriter.NewWriter(w, 10, 4, 2, ' ', 0)
 	var line string
 	line = "Resource\tRequirement\tImplementation\tAttestation\tEvaluated At\tResult\tLogs\n"
-	_, err := w.Write([]byte(line))
+	_, err := tw.Write([]byte(line))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 	}
@@ -54,7 +61,7 @@ func (t *TSVSummary) Detailed(c *models.Configuration) {
 		logs := "N/A"
 		if len(r.Requirements) == 0 {
 			line = fmt.Sprintf("%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", r.Name, printReq, printImp, printAtt, ranAt, result, logs)
-			_, err := w.Write([]byte(line))
+			_, err := tw.Write([]byte(line))
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 			}
@@ -63,7 +70,7 @@ func (t *TSVSummary) Detailed(c *models.Configuration) {
 			printReq = req.Requirement.Name
 			if len(req.Implementations) == 0 {
 				line = fmt.Sprintf("%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", r.Name, printReq, printImp, printAtt, ranAt, result, logs)
-				_, err := w.Write([]byte(line))
+				_, err := tw.Write([]byte(line))
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 				}
@@ -72,7 +79,7 @@ func (t *TSVSummary) Detailed(c *models.Configuration) {
 				printImp = imp.Implementation.Name
 				if len(imp.Attestation) == 0 {
 					line = fmt.Sprintf("%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", r.Name, printReq, printImp, printAtt, ranAt, result, logs)
-					_, err := w.Write([]byte(line))
+					_, err := tw.Write([]byte(line))
 					if err != nil {
 						fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 					}
@@ -83,7 +90,7 @@ func (t *TSVSummary) Detailed(c *models.Configuration) {
 					result = ats.Attestation.Result.Result
 					logs = ats.Attestation.Result.Logs
 					line = fmt.Sprintf("%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", r.Name, printReq, printImp, printAtt, ranAt, result, logs)
-					_, err := w.Write([]byte(line))
+					_, err := tw.Write([]byte(line))
 					if err != nil {
 						fmt.Fprintf(os.Stderr, "error happened while printing to output:%v", err)
 					}
@@ -91,5 +98,5 @@ func (t *TSVSummary) Detailed(c *models.Configuration) {
 			}
 		}
 	}
-	w.Flush()
+	tw.Flush()
 }
@@ -8,6 +8,17 @@ type StorageDriver interface {
 	Configure(config map[string]interface{}) error
 }
 
+// HistoryDriver is implemented by storage drivers that retain every attestation run
+// instead of only the latest state.
+type HistoryDriver interface {
+	// Runs lists every saved run, oldest first.
+	Runs() ([]models.Run, error)
+	// LoadRun returns the configuration as it was saved in the given run.
+	LoadRun(id int64) (*models.Configuration, error)
+	// History returns every recorded attestation result matching the filter, oldest first.
+	History(filter models.HistoryFilter) ([]models.AttestationRecord, error)
+}
+
 var Registry = make(map[string]StorageDriver)
 
 func Register(name string, driver StorageDriver) {
@@ -0,0 +1,259 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/storage/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+
+	// registers the pure Go "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	schema.Register("sqlite", &SQLiteStorage{})
+}
+
+const migration = `
+CREATE TABLE IF NOT EXISTS runs (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	saved_at      INTEGER NOT NULL,
+	configuration TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS attestation_results (
+	id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+	run_id              INTEGER NOT NULL REFERENCES runs(id),
+	resource            TEXT NOT NULL,
+	requirement         TEXT NOT NULL,
+	requirement_code    TEXT NOT NULL,
+	requirement_version TEXT NOT NULL,
+	implementation      TEXT NOT NULL,
+	attestation         TEXT NOT NULL,
+	type                TEXT NOT NULL,
+	command             TEXT NOT NULL,
+	result              TEXT NOT NULL,
+	reason              TEXT NOT NULL,
+	err                 TEXT NOT NULL,
+	logs                TEXT NOT NULL,
+	run_at              INTEGER NOT NULL,
+	UNIQUE (resource, requirement, implementation, attestation, run_at)
+);
+CREATE INDEX IF NOT EXISTS attestation_results_run_at ON attestation_results (run_at);
+`
+
+// SQLiteStorage keeps every saved configuration and an append-only log of
+// attestation results, so that past runs can be queried.
+type SQLiteStorage struct {
+	fileName string
+	db       *sql.DB
+}
+
+func (s *SQLiteStorage) Configure(config map[string]interface{}) error {
+	var ok bool
+	s.fileName, ok = config["file"].(string)
+	if !ok {
+		return fmt.Errorf("could not configure file path. is this name valid?")
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%v?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", s.fileName))
+	if err != nil {
+		return fmt.Errorf("could not open database %v:%w", s.fileName, err)
+	}
+	_, err = db.Exec(migration)
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("could not migrate database %v:%w", s.fileName, err)
+	}
+	if s.db != nil {
+		s.db.Close()
+	}
+	s.db = db
+	return nil
+}
+
+func (s *SQLiteStorage) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+// Save stores the configuration as a new run. Attestation results that were not
+// recorded by a previous run are appended to the history.
+func (s *SQLiteStorage) Save(config *models.Configuration) error {
+	if s.db == nil {
+		return errors.New("database is not configured")
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("could not encode configuration:%w", err)
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("could not start transaction:%w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+	res, err := tx.Exec(`INSERT INTO runs (saved_at, configuration) VALUES (?, ?)`, time.Now().UTC().UnixNano(), string(data))
+	if err != nil {
+		return fmt.Errorf("could not save run:%w", err)
+	}
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("could not save run:%w", err)
+	}
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO attestation_results
+		(run_id, resource, requirement, requirement_code, requirement_version, implementation, attestation, type, command, result, reason, err, logs, run_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("could not prepare statement:%w", err)
+	}
+	defer stmt.Close()
+	for _, rec := range records(config) {
+		r := rec.Result
+		_, err = stmt.Exec(runID, rec.Resource, rec.Requirement, rec.RequirementCode, rec.RequirementVersion, rec.Implementation,
+			rec.Attestation, rec.Type, r.Command, r.Result, r.Reason, r.Err, r.Logs, r.RunAt.UTC().UnixNano())
+		if err != nil {
+			return fmt.Errorf("could not save result for attestation %v:%w", rec.Attestation, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Load returns the configuration saved by the latest run.
+func (s *SQLiteStorage) Load() (*models.Configuration, error) {
+	if s.db == nil {
+		return nil, errors.New("database is not configured")
+	}
+	row := s.db.QueryRow(`SELECT configuration FROM runs ORDER BY id DESC LIMIT 1`)
+	return decode(row, s.fileName)
+}
+
+func (s *SQLiteStorage) LoadRun(id int64) (*models.Configuration, error) {
+	if s.db == nil {
+		return nil, errors.New("database is not configured")
+	}
+	row := s.db.QueryRow(`SELECT configuration FROM runs WHERE id = ?`, id)
+	return decode(row, fmt.Sprintf("run %v", id))
+}
+
+func (s *SQLiteStorage) Runs() ([]models.Run, error) {
+	if s.db == nil {
+		return nil, errors.New("database is not configured")
+	}
+	rows, err := s.db.Query(`SELECT r.id, r.saved_at, COUNT(a.id) FROM runs r
+		LEFT JOIN attestation_results a ON a.run_id = r.id
+		GROUP BY r.id ORDER BY r.id`)
+	if err != nil {
+		return nil, fmt.Errorf("could not list runs:%w", err)
+	}
+	defer rows.Close()
+	runs := []models.Run{}
+	for rows.Next() {
+		run := models.Run{}
+		var savedAt int64
+		err = rows.Scan(&run.ID, &savedAt, &run.Results)
+		if err != nil {
+			return nil, fmt.Errorf("could not read run:%w", err)
+		}
+		run.SavedAt = time.Unix(0, savedAt).UTC()
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (s *SQLiteStorage) History(filter models.HistoryFilter) ([]models.AttestationRecord, error) {
+	if s.db == nil {
+		return nil, errors.New("database is not configured")
+	}
+	query := `SELECT run_id, resource, requirement, requirement_code, requirement_version, implementation, attestation, type,
+		command, result, reason, err, logs, run_at FROM attestation_results WHERE 1 = 1`
+	args := []interface{}{}
+	if filter.Resource != "" {
+		query = query + ` AND resource = ?`
+		args = append(args, filter.Resource)
+	}
+	if filter.RequirementCode != "" {
+		query = query + ` AND requirement_code = ?`
+		args = append(args, filter.RequirementCode)
+	}
+	if !filter.Since.IsZero() {
+		query = query + ` AND run_at >= ?`
+		args = append(args, filter.Since.UTC().UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		query = query + ` AND run_at <= ?`
+		args = append(args, filter.Until.UTC().UnixNano())
+	}
+	query = query + ` ORDER BY run_at, id`
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not query history:%w", err)
+	}
+	defer rows.Close()
+	history := []models.AttestationRecord{}
+	for rows.Next() {
+		rec := models.AttestationRecord{}
+		var runAt int64
+		err = rows.Scan(&rec.RunID, &rec.Resource, &rec.Requirement, &rec.RequirementCode, &rec.RequirementVersion, &rec.Implementation,
+			&rec.Attestation, &rec.Type, &rec.Result.Command, &rec.Result.Result, &rec.Result.Reason, &rec.Result.Err, &rec.Result.Logs, &runAt)
+		if err != nil {
+			return nil, fmt.Errorf("could not read history:%w", err)
+		}
+		rec.Result.RunAt = time.Unix(0, runAt).UTC()
+		history = append(history, rec)
+	}
+	return history, rows.Err()
+}
+
+func decode(row *sql.Row, name string) (*models.Configuration, error) {
+	var data string
+	err := row.Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("no configuration saved in %v. did you run 'argus load'?", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read %v:%w", name, err)
+	}
+	config := models.Configuration{}
+	err = json.Unmarshal([]byte(data), &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %v:%w", name, err)
+	}
+	return &config, nil
+}
+
+// records lists every attestation result of the configuration that has been run.
+func records(config *models.Configuration) []models.AttestationRecord {
+	recs := []models.AttestationRecord{}
+	for _, r := range config.Resources {
+		for _, reqKey := range utils.SortedKeys(r.Requirements) {
+			req := r.Requirements[reqKey]
+			for _, impKey := range utils.SortedKeys(req.Implementations) {
+				imp := req.Implementations[impKey]
+				for _, attKey := range utils.SortedKeys(imp.Attestation) {
+					att := imp.Attestation[attKey].Attestation
+					if att.Result.Result == "" {
+						continue
+					}
+					recs = append(recs, models.AttestationRecord{
+						Resource:           r.Name,
+						Requirement:        req.Requirement.Name,
+						RequirementCode:    req.Requirement.Code,
+						RequirementVersion: req.Requirement.Version,
+						Implementation:     imp.Implementation.Name,
+						Attestation:        att.Name,
+						Type:               att.Type,
+						Result:             att.Result,
+					})
+				}
+			}
+		}
+	}
+	return recs
+}
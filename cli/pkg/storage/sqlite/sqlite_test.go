@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+
+	"gotest.tools/v3/assert"
+)
+
+func makeConfig(result string, runAt time.Time) *models.Configuration {
+	req := &models.Requirement{Name: "VMs cannot have data disks", Code: "REQ-01", Version: "1.0.0"}
+	imp := &models.Implementation{Name: "policy", Class: "Preventative"}
+	att := &models.Attestation{Name: "check", Type: "command"}
+	if result != "" {
+		att.Result = models.AttestationResult{Result: result, Reason: "reason-" + result, Logs: "logs", RunAt: runAt}
+	}
+	return &models.Configuration{
+		Requirements: []models.Requirement{*req},
+		Resources: []models.Resource{
+			{
+				Name: "vm",
+				Requirements: map[string]models.RequirementBlock{
+					req.Name: {
+						Requirement: req,
+						Implementations: map[string]models.ImplementationBlock{
+							imp.Name: {
+								Implementation: imp,
+								Attestation: map[string]models.AttestationBlock{
+									att.Name: {Attestation: att},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newStorage(t *testing.T) *SQLiteStorage {
+	s := &SQLiteStorage{}
+	err := s.Configure(map[string]interface{}{"file": filepath.Join(t.TempDir(), "argus.db")})
+	assert.NilError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestConfigure(t *testing.T) {
+	s := &SQLiteStorage{}
+	err := s.Configure(map[string]interface{}{})
+	assert.ErrorContains(t, err, "could not configure file path")
+	_, err = newStorage(t).Load()
+	assert.ErrorContains(t, err, "no configuration saved")
+}
+
+func TestSaveLoad(t *testing.T) {
+	s := newStorage(t)
+	first := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	assert.NilError(t, s.Save(makeConfig("", time.Time{})))
+	assert.NilError(t, s.Save(makeConfig("FAIL", first)))
+	// Saving the same results again must not duplicate history.
+	assert.NilError(t, s.Save(makeConfig("FAIL", first)))
+	assert.NilError(t, s.Save(makeConfig("PASS", second)))
+
+	latest, err := s.Load()
+	assert.NilError(t, err)
+	res := latest.Resources[0].Requirements["VMs cannot have data disks"].Implementations["policy"].Attestation["check"].Attestation.Result
+	assert.Equal(t, "PASS", res.Result)
+	assert.Assert(t, res.RunAt.Equal(second))
+
+	runs, err := s.Runs()
+	assert.NilError(t, err)
+	assert.Equal(t, 4, len(runs))
+	assert.DeepEqual(t, []int{0, 1, 0, 1}, []int{runs[0].Results, runs[1].Results, runs[2].Results, runs[3].Results})
+
+	old, err := s.LoadRun(runs[1].ID)
+	assert.NilError(t, err)
+	res = old.Resources[0].Requirements["VMs cannot have data disks"].Implementations["policy"].Attestation["check"].Attestation.Result
+	assert.Equal(t, "FAIL", res.Result)
+	_, err = s.LoadRun(42)
+	assert.ErrorContains(t, err, "no configuration saved in run 42")
+}
+
+func TestHistory(t *testing.T) {
+	s := newStorage(t)
+	first := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	assert.NilError(t, s.Save(makeConfig("FAIL", first)))
+	assert.NilError(t, s.Save(makeConfig("PASS", second)))
+
+	testCase := []struct {
+		name            string
+		filter          models.HistoryFilter
+		expectedResults []string
+	}{
+		{name: "All", filter: models.HistoryFilter{}, expectedResults: []string{"FAIL", "PASS"}},
+		{name: "ByResource", filter: models.HistoryFilter{Resource: "vm"}, expectedResults: []string{"FAIL", "PASS"}},
+		{name: "UnknownResource", filter: models.HistoryFilter{Resource: "other"}, expectedResults: []string{}},
+		{name: "ByRequirementCode", filter: models.HistoryFilter{RequirementCode: "REQ-01"}, expectedResults: []string{"FAIL", "PASS"}},
+		{name: "Since", filter: models.HistoryFilter{Since: second}, expectedResults: []string{"PASS"}},
+		{name: "Until", filter: models.HistoryFilter{Until: first}, expectedResults: []string{"FAIL"}},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			history, err := s.History(tc.filter)
+			assert.NilError(t, err)
+			results := []string{}
+			for _, rec := range history {
+				results = append(results, rec.Result.Result)
+				assert.Equal(t, "REQ-01", rec.RequirementCode)
+				assert.Equal(t, "check", rec.Attestation)
+				assert.Equal(t, "reason-"+rec.Result.Result, rec.Result.Reason)
+			}
+			assert.DeepEqual(t, tc.expectedResults, results)
+		})
+	}
+}
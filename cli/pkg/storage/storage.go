@@ -5,6 +5,7 @@ import (
 
 	_ "github.com/ContainerSolutions/argus/cli/pkg/storage/file"
 	"github.com/ContainerSolutions/argus/cli/pkg/storage/schema"
+	_ "github.com/ContainerSolutions/argus/cli/pkg/storage/sqlite"
 )
 
 func Init(name string) (schema.StorageDriver, error) {
@@ -1,5 +1,7 @@
 package utils
 
+import "sort"
+
 func Contains(s []string, str string) bool {
 	for _, v := range s {
 		if v == str {
@@ -9,3 +11,13 @@ func Contains(s []string, str string) bool {
 
 	return false
 }
+
+// SortedKeys returns the keys of a map in order, so that maps are walked deterministically.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,173 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/attester"
+	"github.com/ContainerSolutions/argus/cli/pkg/attester/schema"
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/parser"
+	"github.com/ContainerSolutions/argus/cli/pkg/resolver"
+	"github.com/ContainerSolutions/argus/cli/pkg/utils"
+)
+
+// Problem is a single integrity issue found in the configuration.
+type Problem struct {
+	Path    string
+	Kind    string
+	Name    string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%v: %v '%v': %v", p.Path, p.Kind, p.Name, p.Message)
+}
+
+// Validate checks the referential integrity of a parsed configuration.
+// Sources may be nil, in which case problems have no path.
+func Validate(c *models.Configuration, s *parser.Sources) []Problem {
+	if s == nil {
+		s = &parser.Sources{}
+	}
+	problems := []Problem{}
+	problems = append(problems, validateResources(c, s)...)
+	problems = append(problems, validateRequirements(c, s)...)
+	problems = append(problems, validateImplementations(c, s)...)
+	problems = append(problems, validateAttestations(c, s)...)
+	return problems
+}
+
+func validateResources(c *models.Configuration, s *parser.Sources) []Problem {
+	problems := []Problem{}
+	resources := map[string]bool{}
+	for _, r := range c.Resources {
+		resources[r.Name] = true
+	}
+	seen := map[string]string{}
+	for i, r := range c.Resources {
+		report := reporter(&problems, "Resource", r.Name, source(s.Resources, i))
+		if r.Name == "" {
+			report("name is required")
+		}
+		if path, ok := seen[r.Name]; ok {
+			report(fmt.Sprintf("duplicate name, already defined in %v", path))
+		}
+		seen[r.Name] = source(s.Resources, i)
+		for _, parent := range r.Parents {
+			if !resources[parent] {
+				report(fmt.Sprintf("parent '%v' does not match any resource", parent))
+			}
+		}
+	}
+	_, err := resolver.NewHierarchy(c.Resources)
+	var cycle *resolver.CycleError
+	if errors.As(err, &cycle) {
+		for i, r := range c.Resources {
+			if r.Name == cycle.Path[0] {
+				problems = append(problems, Problem{Path: source(s.Resources, i), Kind: "Resource", Name: r.Name, Message: err.Error()})
+				break
+			}
+		}
+	}
+	return problems
+}
+
+func validateRequirements(c *models.Configuration, s *parser.Sources) []Problem {
+	problems := []Problem{}
+	seen := map[models.RequirementRef]string{}
+	for i, req := range c.Requirements {
+		report := reporter(&problems, "Requirement", req.Name, source(s.Requirements, i))
+		if req.Code == "" {
+			report("code is required")
+		}
+		ref := models.RequirementRef{Code: req.Code, Version: req.Version}
+		if path, ok := seen[ref]; ok {
+			report(fmt.Sprintf("duplicate code '%v' version '%v', already defined in %v", req.Code, req.Version, path))
+		}
+		seen[ref] = source(s.Requirements, i)
+	}
+	return problems
+}
+
+func validateImplementations(c *models.Configuration, s *parser.Sources) []Problem {
+	problems := []Problem{}
+	resources := map[string]bool{}
+	for _, r := range c.Resources {
+		resources[r.Name] = true
+	}
+	versions := map[string][]string{}
+	for _, req := range c.Requirements {
+		versions[req.Code] = append(versions[req.Code], req.Version)
+	}
+	seen := map[string]string{}
+	for i, imp := range c.Implementations {
+		report := reporter(&problems, "Implementation", imp.Name, source(s.Implementations, i))
+		if path, ok := seen[imp.Name]; ok {
+			report(fmt.Sprintf("duplicate name, already defined in %v", path))
+		}
+		seen[imp.Name] = source(s.Implementations, i)
+		ref := imp.RequirementRef
+		known, ok := versions[ref.Code]
+		switch {
+		case !ok:
+			report(fmt.Sprintf("requirementRef code '%v' does not match any requirement", ref.Code))
+		case !utils.Contains(known, ref.Version):
+			sort.Strings(known)
+			report(fmt.Sprintf("requirementRef code '%v' has no version '%v'. known versions are: %v", ref.Code, ref.Version, strings.Join(known, ", ")))
+		}
+		if len(imp.ResourceRef) == 0 {
+			report("resourceRef must name at least one resource")
+		}
+		for _, ref := range imp.ResourceRef {
+			if !resources[ref] {
+				report(fmt.Sprintf("resourceRef '%v' does not match any resource", ref))
+			}
+		}
+	}
+	return problems
+}
+
+func validateAttestations(c *models.Configuration, s *parser.Sources) []Problem {
+	problems := []Problem{}
+	implementations := map[string]bool{}
+	for _, imp := range c.Implementations {
+		implementations[imp.Name] = true
+	}
+	types := []string{}
+	for name := range schema.Registry {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	seen := map[string]string{}
+	for i, att := range c.Attestations {
+		report := reporter(&problems, "Attestation", att.Name, source(s.Attestations, i))
+		key := att.ImplementationRef + "/" + att.Name
+		if path, ok := seen[key]; ok {
+			report(fmt.Sprintf("duplicate name for implementation '%v', already defined in %v", att.ImplementationRef, path))
+		}
+		seen[key] = source(s.Attestations, i)
+		if !implementations[att.ImplementationRef] {
+			report(fmt.Sprintf("implementationRef '%v' does not match any implementation", att.ImplementationRef))
+		}
+		if _, err := attester.Init(att.Type); err != nil {
+			report(fmt.Sprintf("type '%v' is not a known attester. known types are: %v", att.Type, strings.Join(types, ", ")))
+		}
+	}
+	return problems
+}
+
+func reporter(problems *[]Problem, kind, name, path string) func(string) {
+	return func(msg string) {
+		*problems = append(*problems, Problem{Path: path, Kind: kind, Name: name, Message: msg})
+	}
+}
+
+func source(paths []string, i int) string {
+	if i < len(paths) {
+		return paths[i]
+	}
+	return ""
+}
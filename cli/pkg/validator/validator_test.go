@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/ContainerSolutions/argus/cli/pkg/models"
+	"github.com/ContainerSolutions/argus/cli/pkg/parser"
+
+	"gotest.tools/v3/assert"
+)
+
+func validConfig() *models.Configuration {
+	return &models.Configuration{
+		Resources: []models.Resource{
+			{Name: "cloud"},
+			{Name: "vm", Parents: []string{"cloud"}},
+		},
+		Requirements: []models.Requirement{
+			{Name: "req", Code: "REQ-01", Version: "1.0.0"},
+		},
+		Implementations: []models.Implementation{
+			{Name: "imp", RequirementRef: models.RequirementRef{Code: "REQ-01", Version: "1.0.0"}, ResourceRef: []string{"cloud"}},
+		},
+		Attestations: []models.Attestation{
+			{Name: "att", Type: "command", ImplementationRef: "imp"},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	sources := &parser.Sources{
+		Resources:       []string{"resources/cloud.yaml", "resources/vm.yaml"},
+		Requirements:    []string{"requirements/req.yaml"},
+		Implementations: []string{"implementations/imp.yaml"},
+		Attestations:    []string{"attestations/att.yaml"},
+	}
+	testCase := []struct {
+		name     string
+		mutate   func(c *models.Configuration)
+		expected []string
+	}{
+		{
+			name:     "Valid",
+			mutate:   func(c *models.Configuration) {},
+			expected: []string{},
+		},
+		{
+			name: "DanglingParent",
+			mutate: func(c *models.Configuration) {
+				c.Resources[1].Parents = []string{"nope", "vm"}
+			},
+			expected: []string{
+				"resources/vm.yaml: Resource 'vm': parent 'nope' does not match any resource",
+				"resources/vm.yaml: Resource 'vm': resource parents form a cycle: vm -> vm",
+			},
+		},
+		{
+			name: "ParentCycle",
+			mutate: func(c *models.Configuration) {
+				c.Resources[0].Parents = []string{"vm"}
+			},
+			expected: []string{
+				"resources/cloud.yaml: Resource 'cloud': resource parents form a cycle: cloud -> vm -> cloud",
+			},
+		},
+		{
+			name: "DuplicateResource",
+			mutate: func(c *models.Configuration) {
+				c.Resources[1].Name = "cloud"
+				c.Resources[1].Parents = nil
+			},
+			expected: []string{
+				"resources/vm.yaml: Resource 'cloud': duplicate name, already defined in resources/cloud.yaml",
+			},
+		},
+		{
+			name: "UnknownRequirementCode",
+			mutate: func(c *models.Configuration) {
+				c.Implementations[0].RequirementRef.Code = "REQ-02"
+			},
+			expected: []string{
+				"implementations/imp.yaml: Implementation 'imp': requirementRef code 'REQ-02' does not match any requirement",
+			},
+		},
+		{
+			name: "UnknownRequirementVersion",
+			mutate: func(c *models.Configuration) {
+				c.Implementations[0].RequirementRef.Version = "2.0.0"
+			},
+			expected: []string{
+				"implementations/imp.yaml: Implementation 'imp': requirementRef code 'REQ-01' has no version '2.0.0'. known versions are: 1.0.0",
+			},
+		},
+		{
+			name: "DanglingResourceRef",
+			mutate: func(c *models.Configuration) {
+				c.Implementations[0].ResourceRef = []string{"cloud", "other"}
+			},
+			expected: []string{
+				"implementations/imp.yaml: Implementation 'imp': resourceRef 'other' does not match any resource",
+			},
+		},
+		{
+			name: "DanglingImplementationRef",
+			mutate: func(c *models.Configuration) {
+				c.Attestations[0].ImplementationRef = "other"
+			},
+			expected: []string{
+				"attestations/att.yaml: Attestation 'att': implementationRef 'other' does not match any implementation",
+			},
+		},
+		{
+			name: "UnknownType",
+			mutate: func(c *models.Configuration) {
+				c.Attestations[0].Type = "telepathy"
+			},
+			expected: []string{
+				"attestations/att.yaml: Attestation 'att': type 'telepathy' is not a known attester. known types are: command, http",
+			},
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			c := validConfig()
+			tc.mutate(c)
+			problems := []string{}
+			for _, p := range Validate(c, sources) {
+				problems = append(problems, p.String())
+			}
+			assert.DeepEqual(t, tc.expected, problems)
+		})
+	}
+}
+
+func TestValidateWithoutSources(t *testing.T) {
+	c := validConfig()
+	c.Attestations[0].ImplementationRef = "other"
+	problems := Validate(c, nil)
+	assert.Equal(t, 1, len(problems))
+	assert.Equal(t, "", problems[0].Path)
+}
@@ -23,10 +23,20 @@ import (
 // AssessmentSpec defines the desired state of Assessment
 type AssessmentSpec struct {
 	Class string `json:"class"`
-	//+default="Cascade"
-	CascadePolicy AssessmentCascadePolicy     `json:"cascadePolicy"`
+	// CascadePolicy tells whether the children of targeted Components are targeted as well. Defaults to Cascade.
+	//+kubebuilder:validation:Enum=Cascade;None
+	//+kubebuilder:default=Cascade
+	//+optional
+	CascadePolicy AssessmentCascadePolicy     `json:"cascadePolicy,omitempty"`
 	ControlRef    AssessmentControlDefinition `json:"controlRef"`
-	ComponentRef  []NamespacedName            `json:"componentRef"`
+	//+optional
+	ComponentRef []NamespacedName `json:"componentRef,omitempty"`
+	// ComponentSelector targets every Component in the Assessment namespace whose labels match.
+	//+optional
+	ComponentSelector *metav1.LabelSelector `json:"componentSelector,omitempty"`
+	// ComponentClasses restricts the Components matched by ComponentSelector to those having any of these classes.
+	//+optional
+	ComponentClasses []string `json:"componentClasses,omitempty"`
 }
 
 type AssessmentCascadePolicy string
@@ -42,10 +52,25 @@ type AssessmentStatus struct {
 	Children []NamespacedName `json:"children,omitempty"`
 	//+optional
 	Status string `json:"status,omitempty"`
+	// Conditions hold the Ready state of the object along with its Attested condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:resource:categories=argus,shortName=asmt
+//+kubebuilder:printcolumn:name="Control",type=string,JSONPath=`.spec.controlRef.code`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.controlRef.version`
+//+kubebuilder:printcolumn:name="Class",type=string,JSONPath=`.spec.class`
+//+kubebuilder:printcolumn:name="Cascade",type=string,JSONPath=`.spec.cascadePolicy`,priority=1
+//+kubebuilder:printcolumn:name="Attested",type=string,JSONPath=`.status.conditions[?(@.type=="Attested")].status`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Assessment is the Schema for the Assessments API
 type Assessment struct {
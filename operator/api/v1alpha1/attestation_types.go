@@ -24,6 +24,10 @@ import (
 type AttestationSpec struct {
 	AssessmentRef string                 `json:"assessmentRef"`
 	ProviderRef   AttestationProviderRef `json:"providerRef"`
+	// Schedule is how often the attestation runs, either as an interval such as '5m'
+	// or as a cron expression such as '0 2 * * *'. Defaults to the provider schedule.
+	//+optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 type AttestationProviderRef struct {
@@ -37,10 +41,24 @@ type AttestationStatus struct {
 	Children []NamespacedName `json:"children,omitempty"`
 	//+optional
 	Status string `json:"status,omitempty"`
+	// Conditions hold the Ready state of the object along with its Attested condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:resource:categories=argus,shortName=attest
+//+kubebuilder:printcolumn:name="Assessment",type=string,JSONPath=`.spec.assessmentRef`
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerRef.name`
+//+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`,priority=1
+//+kubebuilder:printcolumn:name="Attested",type=string,JSONPath=`.status.conditions[?(@.type=="Attested")].status`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Attestation is the Schema for the attestations API
 type Attestation struct {
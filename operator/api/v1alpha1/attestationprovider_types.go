@@ -24,13 +24,49 @@ import (
 type AttestationProviderSpec struct {
 	Type           string            `json:"type"`
 	ProviderConfig map[string]string `json:"providerConfig"`
+	// Timeout bounds a single attestation run. Defaults to 5m.
+	//+optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Schedule is how often attestations using this provider run when they do not set their own,
+	// either as an interval such as '5m' or as a cron expression such as '0 2 * * *'.
+	//+optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 // AttestationProviderStatus defines the observed state of AttestationProvider
-type AttestationProviderStatus struct{}
+type AttestationProviderStatus struct {
+	// Attestations is the number of ComponentAttestations using the provider.
+	//+optional
+	Attestations int `json:"attestations"`
+	// FailingAttestations is the number of those whose last run failed with a provider error.
+	//+optional
+	FailingAttestations int `json:"failingAttestations"`
+	// LastError is the most recent error of the provider, either from its configuration
+	// or from one of its attestations.
+	//+optional
+	LastError string `json:"lastError,omitempty"`
+	//+optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+	// Conditions hold the Ready state of the object along with its ProviderError condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:resource:categories=argus,shortName=attprov
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Attestations",type=integer,JSONPath=`.status.attestations`
+//+kubebuilder:printcolumn:name="Failing",type=integer,JSONPath=`.status.failingAttestations`
+//+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+//+kubebuilder:printcolumn:name="Timeout",type=string,JSONPath=`.spec.timeout`,priority=1
+//+kubebuilder:printcolumn:name="Last Error",type=string,JSONPath=`.status.lastError`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // AttestationProvider is the Schema for the attestationproviders API
 type AttestationProvider struct {
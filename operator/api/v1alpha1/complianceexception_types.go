@@ -0,0 +1,96 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComplianceExceptionSpec defines the desired state of ComplianceException
+type ComplianceExceptionSpec struct {
+	// ControlRef is the Control waived on the targeted Components.
+	ControlRef AssessmentControlDefinition `json:"controlRef"`
+	// ComponentRef lists the names of the Components the exception applies to, in its namespace.
+	//+optional
+	ComponentRef []string `json:"componentRef,omitempty"`
+	// ComponentSelector targets every Component in the exception namespace whose labels match.
+	//+optional
+	ComponentSelector *metav1.LabelSelector `json:"componentSelector,omitempty"`
+	// AssessmentRef restricts the exception to the ComponentAssessments of the named Assessment.
+	// The whole Control is waived when it is empty.
+	//+optional
+	AssessmentRef string `json:"assessmentRef,omitempty"`
+	// Justification explains why the Control can not be met for now.
+	//+kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+	// Approver is who accepted the exception.
+	//+kubebuilder:validation:MinLength=1
+	Approver string `json:"approver"`
+	// ExpiresAt is when the exception lapses, after which the Control counts again.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// ComplianceExceptionStatus defines the observed state of ComplianceException
+type ComplianceExceptionStatus struct {
+	// Active is true until the exception expires.
+	//+optional
+	Active bool `json:"active"`
+	// Components lists the names of the Components the exception currently targets.
+	//+optional
+	Components []string `json:"components,omitempty"`
+	// Conditions hold the Ready state of the object along with its Active condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:categories=argus,shortName=cex
+//+kubebuilder:printcolumn:name="Control",type=string,JSONPath=`.spec.controlRef.code`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.controlRef.version`
+//+kubebuilder:printcolumn:name="Assessment",type=string,JSONPath=`.spec.assessmentRef`,priority=1
+//+kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.spec.approver`
+//+kubebuilder:printcolumn:name="Expires At",type=string,JSONPath=`.spec.expiresAt`
+//+kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.conditions[?(@.type=="Active")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ComplianceException is the Schema for the ComplianceExceptions API.
+// It waives a Control on some Components until it expires.
+type ComplianceException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComplianceExceptionSpec   `json:"spec,omitempty"`
+	Status ComplianceExceptionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComplianceExceptionList contains a list of ComplianceException
+type ComplianceExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComplianceException `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComplianceException{}, &ComplianceExceptionList{})
+}
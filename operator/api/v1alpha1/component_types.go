@@ -33,6 +33,9 @@ type ComponentStatus struct {
 	TotalControls int `json:"totalControls"`
 	//+kubebuilder:default=0
 	ImplementedControls int `json:"implementedControls"`
+	// WaivedControls counts the Controls that are not implemented but waived by a ComplianceException.
+	//+kubebuilder:default=0
+	WaivedControls int `json:"waivedControls"`
 	//+optional
 	Children map[string]ComponentChild `json:"children,omitempty"`
 	//+optional
@@ -43,10 +46,19 @@ type ComponentStatus struct {
 	CompliantChildren int `json:"compliantChildren"`
 	//+optional
 	RunAt metav1.Time `json:"runAt,omitempty"`
+	// Conditions hold the Ready state of the object along with its Compliant condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 type ComponentControlCompliance struct {
 	Implemented bool `json:"implemented"`
+	//+optional
+	Waived bool `json:"waived,omitempty"`
 }
 
 // All parent relationship is flattened. TODO - maybe we want to have the whole hierarchy here?
@@ -61,9 +73,16 @@ type ComponentChild struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=argus,shortName=comp
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Compliant",type=string,JSONPath=`.status.conditions[?(@.type=="Compliant")].status`
 // +kubebuilder:printcolumn:name="Total Controls",type=integer,JSONPath=`.status.totalControls`
 // +kubebuilder:printcolumn:name="Implemented Controls",type=integer,JSONPath=`.status.implementedControls`
+// +kubebuilder:printcolumn:name="Waived Controls",type=integer,JSONPath=`.status.waivedControls`
+// +kubebuilder:printcolumn:name="Children",type=integer,JSONPath=`.status.totalChildren`,priority=1
+// +kubebuilder:printcolumn:name="Compliant Children",type=integer,JSONPath=`.status.compliantChildren`,priority=1
 // +kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.runAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type Component struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
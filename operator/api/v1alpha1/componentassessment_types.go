@@ -41,14 +41,27 @@ type ComponentAssessmentStatus struct {
 	PassedAttestations int `json:"passedAttestations"`
 	//+optional
 	RunAt metav1.Time `json:"runAt,omitempty"`
+	// Conditions hold the Ready state of the object along with its Attested condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ComponentAssessment is the Schema for the ComponentAssessments API
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=argus,shortName=compasmt
+// +kubebuilder:printcolumn:name="Component",type=string,JSONPath=`.metadata.labels.argus\.io/Component`
+// +kubebuilder:printcolumn:name="Control",type=string,JSONPath=`.spec.ControlRef.code`
+// +kubebuilder:printcolumn:name="Class",type=string,JSONPath=`.spec.class`,priority=1
+// +kubebuilder:printcolumn:name="Attested",type=string,JSONPath=`.status.conditions[?(@.type=="Attested")].status`
 // +kubebuilder:printcolumn:name="Total Attestations",type=integer,JSONPath=`.status.totalAttestations`
 // +kubebuilder:printcolumn:name="Passed Attestations",type=integer,JSONPath=`.status.passedAttestations`
 // +kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.runAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type ComponentAssessment struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -23,12 +23,27 @@ import (
 // ComponentAttestationSpec defines the desired state of ComponentAttestation
 type ComponentAttestationSpec struct {
 	ProviderRef AttestationProviderRef `json:"providerRef"`
+	// Schedule is copied from the Attestation.
+	//+optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 // ComponentAttestationStatus defines the observed state of ComponentAttestation
 type ComponentAttestationStatus struct {
 	Result AttestationResult `json:"result"`
 	Status string            `json:"status"`
+	//+optional
+	LastRunAt *metav1.Time `json:"lastRunAt,omitempty"`
+	//+optional
+	NextRunAt *metav1.Time `json:"nextRunAt,omitempty"`
+	// ObservedGeneration is the generation last attested. A newer generation is attested right away.
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions hold the Ready state of the object along with its Attested and ProviderError conditions.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type AttestationResult struct {
@@ -54,8 +69,14 @@ const (
 // ComponentAttestation is the Schema for the Componentattestations API
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=argus,shortName=compattest
+// +kubebuilder:printcolumn:name="Component",type=string,JSONPath=`.metadata.labels.argus\.io/Component`
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerRef.name`,priority=1
 // +kubebuilder:printcolumn:name="Result",type=string,JSONPath=`.status.result.result`
+// +kubebuilder:printcolumn:name="Provider Error",type=string,JSONPath=`.status.conditions[?(@.type=="ProviderError")].status`,priority=1
 // +kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.result.runAt`
+// +kubebuilder:printcolumn:name="Next Run",type=string,JSONPath=`.status.nextRunAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type ComponentAttestation struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -34,20 +34,42 @@ type ComponentControlStatus struct {
 	TotalAssessments int `json:"totalAssessments"`
 	//+kubebuilder:default=0
 	ValidAssessments int `json:"validAssessments"`
+	// WaivedAssessments counts the applicable ComponentAssessments waived by a ComplianceException, and not attested.
+	//+kubebuilder:default=0
+	WaivedAssessments int `json:"waivedAssessments"`
+	// Waived is true when the Control is not implemented, but waived by ComplianceExceptions.
+	//+optional
+	Waived bool `json:"waived,omitempty"`
+	// Exceptions lists the active ComplianceExceptions waiving the Control, or some of its Assessments, on the Component.
+	//+optional
+	Exceptions []NamespacedName `json:"exceptions,omitempty"`
 	//+optional
 	Status string `json:"status,omitempty"`
 	//+optional
 	ControlHash string `json:"ControlHash,omitempty"`
 	//+optional
 	RunAt metav1.Time `json:"runAt,omitempty"`
+	// Conditions hold the Ready state of the object along with its Compliant condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ComponentControl is the Schema for the ComponentControls API
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=argus,shortName=compctrl
+// +kubebuilder:printcolumn:name="Component",type=string,JSONPath=`.metadata.labels.argus\.io/Component`
+// +kubebuilder:printcolumn:name="Control",type=string,JSONPath=`.spec.definition.code`
+// +kubebuilder:printcolumn:name="Compliant",type=string,JSONPath=`.status.conditions[?(@.type=="Compliant")].status`
 // +kubebuilder:printcolumn:name="Total Assessments",type=integer,JSONPath=`.status.totalAssessments`
 // +kubebuilder:printcolumn:name="Valid Assessments",type=integer,JSONPath=`.status.validAssessments`
+// +kubebuilder:printcolumn:name="Waived Assessments",type=integer,JSONPath=`.status.waivedAssessments`,priority=1
 // +kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.runAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type ComponentControl struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
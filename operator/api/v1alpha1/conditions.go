@@ -0,0 +1,48 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Condition types set on the status of argus objects.
+const (
+	// ConditionTypeReady is True when the object was reconciled without errors.
+	ConditionTypeReady = "Ready"
+	// ConditionTypeAttested is True when every attestation below the object passed.
+	ConditionTypeAttested = "Attested"
+	// ConditionTypeCompliant is True when every control applicable to the object is implemented.
+	ConditionTypeCompliant = "Compliant"
+	// ConditionTypeProviderError is True when the attestation provider could not run.
+	ConditionTypeProviderError = "ProviderError"
+	// ConditionTypeActive is True until a ComplianceException expires.
+	ConditionTypeActive = "Active"
+)
+
+// Condition reasons set on the status of argus objects.
+const (
+	ConditionReasonReconciled     = "Reconciled"
+	ConditionReasonReconcileError = "ReconcileError"
+	ConditionReasonPassed         = "Passed"
+	ConditionReasonFailed         = "Failed"
+	ConditionReasonUnknown        = "Unknown"
+	ConditionReasonNotStarted     = "NotStarted"
+	ConditionReasonImplemented    = "Implemented"
+	ConditionReasonNotImplemented = "NotImplemented"
+	ConditionReasonProviderFailed = "ProviderFailed"
+	ConditionReasonProviderOK     = "ProviderOK"
+	ConditionReasonInvalidConfig  = "InvalidConfig"
+	ConditionReasonWaived         = "Waived"
+	ConditionReasonExpired        = "Expired"
+)
@@ -41,6 +41,13 @@ type ControlStatus struct {
 	//+optional
 	Children    []NamespacedName `json:"children,omitempty"`
 	ControlHash string           `json:"ControlHash"`
+	// Conditions hold the Ready state of the object along with its Compliant condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 type NamespacedName struct {
@@ -50,6 +57,14 @@ type NamespacedName struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:resource:categories=argus,shortName=ctrl
+//+kubebuilder:printcolumn:name="Code",type=string,JSONPath=`.spec.definition.code`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.definition.version`
+//+kubebuilder:printcolumn:name="Class",type=string,JSONPath=`.spec.definition.class`,priority=1
+//+kubebuilder:printcolumn:name="Category",type=string,JSONPath=`.spec.definition.category`,priority=1
+//+kubebuilder:printcolumn:name="Compliant",type=string,JSONPath=`.status.conditions[?(@.type=="Compliant")].status`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Control is the Schema for the Controls API
 type Control struct {
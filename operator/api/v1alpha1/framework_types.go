@@ -0,0 +1,104 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FrameworkSpec defines the desired state of Framework
+type FrameworkSpec struct {
+	// Title is the human readable name of the framework, such as "CIS Kubernetes Benchmark".
+	//+optional
+	Title string `json:"title,omitempty"`
+	// Version is the version of the framework, such as "1.8".
+	//+kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+	//+optional
+	Description string `json:"description,omitempty"`
+	// ControlRefs lists the Controls of the framework by code and version.
+	//+optional
+	ControlRefs []AssessmentControlDefinition `json:"controlRefs,omitempty"`
+	// ControlSelector adds every Control in the framework namespace whose labels match.
+	//+optional
+	ControlSelector *metav1.LabelSelector `json:"controlSelector,omitempty"`
+}
+
+// FrameworkStatus defines the observed state of Framework
+type FrameworkStatus struct {
+	// Controls lists the Controls of the framework, as the value of their argus.io/Control label.
+	//+optional
+	Controls []string `json:"controls,omitempty"`
+	// Components holds the compliance of every Component of the framework namespace at least one Control of the framework applies to, by name.
+	//+optional
+	Components map[string]*FrameworkComponentCompliance `json:"components,omitempty"`
+	//+kubebuilder:default=0
+	TotalComponents int `json:"totalComponents"`
+	//+kubebuilder:default=0
+	CompliantComponents int `json:"compliantComponents"`
+	//+optional
+	RunAt metav1.Time `json:"runAt,omitempty"`
+	// Conditions hold the Ready state of the object along with its Compliant condition.
+	//+optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// FrameworkComponentCompliance is the compliance of a Component with the Controls of a framework that apply to it.
+type FrameworkComponentCompliance struct {
+	TotalControls       int  `json:"totalControls"`
+	ImplementedControls int  `json:"implementedControls"`
+	WaivedControls      int  `json:"waivedControls"`
+	Compliant           bool `json:"compliant"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:categories=argus,shortName=fw
+//+kubebuilder:printcolumn:name="Title",type=string,JSONPath=`.spec.title`,priority=1
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Compliant",type=string,JSONPath=`.status.conditions[?(@.type=="Compliant")].status`
+//+kubebuilder:printcolumn:name="Components",type=integer,JSONPath=`.status.totalComponents`
+//+kubebuilder:printcolumn:name="Compliant Components",type=integer,JSONPath=`.status.compliantComponents`
+//+kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.runAt`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Framework is the Schema for the Frameworks API.
+// It groups Controls into a versioned profile, such as CIS or ISO 27001, and reports compliance against it.
+type Framework struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FrameworkSpec   `json:"spec,omitempty"`
+	Status FrameworkStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FrameworkList contains a list of Framework
+type FrameworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Framework `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Framework{}, &FrameworkList{})
+}
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -108,6 +109,16 @@ func (in *AssessmentSpec) DeepCopyInto(out *AssessmentSpec) {
 		*out = make([]NamespacedName, len(*in))
 		copy(*out, *in)
 	}
+	if in.ComponentSelector != nil {
+		in, out := &in.ComponentSelector, &out.ComponentSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComponentClasses != nil {
+		in, out := &in.ComponentClasses, &out.ComponentClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssessmentSpec.
@@ -128,6 +139,13 @@ func (in *AssessmentStatus) DeepCopyInto(out *AssessmentStatus) {
 		*out = make([]NamespacedName, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssessmentStatus.
@@ -205,7 +223,7 @@ func (in *AttestationProvider) DeepCopyInto(out *AttestationProvider) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationProvider.
@@ -283,6 +301,11 @@ func (in *AttestationProviderSpec) DeepCopyInto(out *AttestationProviderSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationProviderSpec.
@@ -298,6 +321,17 @@ func (in *AttestationProviderSpec) DeepCopy() *AttestationProviderSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AttestationProviderStatus) DeepCopyInto(out *AttestationProviderStatus) {
 	*out = *in
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationProviderStatus.
@@ -350,6 +384,13 @@ func (in *AttestationStatus) DeepCopyInto(out *AttestationStatus) {
 		*out = make([]NamespacedName, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationStatus.
@@ -362,6 +403,119 @@ func (in *AttestationStatus) DeepCopy() *AttestationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceException) DeepCopyInto(out *ComplianceException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceException.
+func (in *ComplianceException) DeepCopy() *ComplianceException {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceExceptionList) DeepCopyInto(out *ComplianceExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComplianceException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceExceptionList.
+func (in *ComplianceExceptionList) DeepCopy() *ComplianceExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceExceptionSpec) DeepCopyInto(out *ComplianceExceptionSpec) {
+	*out = *in
+	out.ControlRef = in.ControlRef
+	if in.ComponentRef != nil {
+		in, out := &in.ComponentRef, &out.ComponentRef
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComponentSelector != nil {
+		in, out := &in.ComponentSelector, &out.ComponentSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceExceptionSpec.
+func (in *ComplianceExceptionSpec) DeepCopy() *ComplianceExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceExceptionStatus) DeepCopyInto(out *ComplianceExceptionStatus) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceExceptionStatus.
+func (in *ComplianceExceptionStatus) DeepCopy() *ComplianceExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Component) DeepCopyInto(out *Component) {
 	*out = *in
@@ -473,6 +627,13 @@ func (in *ComponentAssessmentStatus) DeepCopyInto(out *ComponentAssessmentStatus
 		copy(*out, *in)
 	}
 	in.RunAt.DeepCopyInto(&out.RunAt)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAssessmentStatus.
@@ -564,6 +725,21 @@ func (in *ComponentAttestationSpec) DeepCopy() *ComponentAttestationSpec {
 func (in *ComponentAttestationStatus) DeepCopyInto(out *ComponentAttestationStatus) {
 	*out = *in
 	in.Result.DeepCopyInto(&out.Result)
+	if in.LastRunAt != nil {
+		in, out := &in.LastRunAt, &out.LastRunAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunAt != nil {
+		in, out := &in.NextRunAt, &out.NextRunAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAttestationStatus.
@@ -694,7 +870,19 @@ func (in *ComponentControlStatus) DeepCopyInto(out *ComponentControlStatus) {
 		*out = make([]NamespacedName, len(*in))
 		copy(*out, *in)
 	}
+	if in.Exceptions != nil {
+		in, out := &in.Exceptions, &out.Exceptions
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
 	in.RunAt.DeepCopyInto(&out.RunAt)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentControlStatus.
@@ -790,6 +978,13 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 		}
 	}
 	in.RunAt.DeepCopyInto(&out.RunAt)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -910,6 +1105,13 @@ func (in *ControlStatus) DeepCopyInto(out *ControlStatus) {
 		*out = make([]NamespacedName, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlStatus.
@@ -922,6 +1124,148 @@ func (in *ControlStatus) DeepCopy() *ControlStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Framework) DeepCopyInto(out *Framework) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Framework.
+func (in *Framework) DeepCopy() *Framework {
+	if in == nil {
+		return nil
+	}
+	out := new(Framework)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Framework) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkComponentCompliance) DeepCopyInto(out *FrameworkComponentCompliance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkComponentCompliance.
+func (in *FrameworkComponentCompliance) DeepCopy() *FrameworkComponentCompliance {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkComponentCompliance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkList) DeepCopyInto(out *FrameworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Framework, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkList.
+func (in *FrameworkList) DeepCopy() *FrameworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkSpec) DeepCopyInto(out *FrameworkSpec) {
+	*out = *in
+	if in.ControlRefs != nil {
+		in, out := &in.ControlRefs, &out.ControlRefs
+		*out = make([]AssessmentControlDefinition, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlSelector != nil {
+		in, out := &in.ControlSelector, &out.ControlSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkSpec.
+func (in *FrameworkSpec) DeepCopy() *FrameworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkStatus) DeepCopyInto(out *FrameworkStatus) {
+	*out = *in
+	if in.Controls != nil {
+		in, out := &in.Controls, &out.Controls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]*FrameworkComponentCompliance, len(*in))
+		for key, val := range *in {
+			var outVal *FrameworkComponentCompliance
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(FrameworkComponentCompliance)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.RunAt.DeepCopyInto(&out.RunAt)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkStatus.
+func (in *FrameworkStatus) DeepCopy() *FrameworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
 	*out = *in
@@ -18,7 +18,9 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,12 +38,17 @@ import (
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/assessment"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/attestation"
+	"github.com/ContainerSolutions/argus/operator/internal/controller/attestationprovider"
+	"github.com/ContainerSolutions/argus/operator/internal/controller/complianceexception"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/component"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/componentassessment"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/componentattestation"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/componentcontrol"
 	"github.com/ContainerSolutions/argus/operator/internal/controller/control"
+	"github.com/ContainerSolutions/argus/operator/internal/controller/framework"
 	"github.com/ContainerSolutions/argus/operator/internal/metrics"
+	"github.com/ContainerSolutions/argus/operator/internal/oscal"
+	webhookv1alpha1 "github.com/ContainerSolutions/argus/operator/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -61,6 +68,10 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var oscalAddr string
+	var oscalCertDir string
+	var resyncInterval time.Duration
+	var attestationInterval time.Duration
 	var lvl zapcore.Level
 	var enc zapcore.TimeEncoder
 	metrics.SetUpMetrics()
@@ -77,9 +88,17 @@ func main() {
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&oscalAddr, "oscal-bind-address", "0",
+		"The address the OSCAL assessment-results endpoint binds to. Set to 0 to disable it.")
+	flag.StringVar(&oscalCertDir, "oscal-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory of the tls.crt and tls.key the OSCAL assessment-results endpoint serves.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"How often objects are reconciled when no change was watched. Set to 0 to only reconcile on changes.")
+	flag.DurationVar(&attestationInterval, "attestation-interval", 1*time.Minute,
+		"How often attestations run when neither they nor their provider set a schedule.")
 	opts := zap.Options{
 		Development: true,
 		Level:       lvl,
@@ -89,6 +108,10 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if attestationInterval <= 0 {
+		setupLog.Error(fmt.Errorf("must be positive, got %v", attestationInterval), "invalid --attestation-interval")
+		os.Exit(1)
+	}
 	config := ctrl.GetConfigOrDie()
 	config.QPS = 500
 	config.Burst = 1500
@@ -118,9 +141,11 @@ func main() {
 	}
 
 	if err = (&attestation.AttestationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 5,
 	}); err != nil {
@@ -128,9 +153,11 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&assessment.AssessmentReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 5,
 	}); err != nil {
@@ -138,9 +165,11 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&control.ControlReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 100,
 	}); err != nil {
@@ -148,9 +177,11 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&component.Reconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 100,
 	}); err != nil {
@@ -158,19 +189,59 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&componentcontrol.ComponentControlReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 100,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ComponentControl")
 		os.Exit(1)
 	}
+	if err = (&attestationprovider.AttestationProviderReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
+	}).SetupWithManager(mgr, controller.Options{
+		MaxConcurrentReconciles: 5,
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AttestationProvider")
+		os.Exit(1)
+	}
+	if err = (&complianceexception.ComplianceExceptionReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
+	}).SetupWithManager(mgr, controller.Options{
+		MaxConcurrentReconciles: 5,
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ComplianceException")
+		os.Exit(1)
+	}
+	if err = (&framework.FrameworkReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
+	}).SetupWithManager(mgr, controller.Options{
+		MaxConcurrentReconciles: 5,
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Framework")
+		os.Exit(1)
+	}
 	if err = (&componentattestation.ComponentAttestationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log,
+		Recorder: mgr.GetEventRecorderFor("argus"),
+		Interval: attestationInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 100,
 	}); err != nil {
@@ -178,17 +249,54 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&componentassessment.ComponentAssessmentReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Log:            ctrl.Log,
+		Recorder:       mgr.GetEventRecorderFor("argus"),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: 100,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ComponentAssessment")
 		os.Exit(1)
 	}
+	// Webhooks need serving certificates, so they can be disabled when running the manager locally.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1alpha1.SetupAssessmentWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Assessment")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupAttestationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Attestation")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupControlWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Control")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupAttestationProviderWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AttestationProvider")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupComplianceExceptionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ComplianceException")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupFrameworkWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Framework")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	// Auditors fetch OSCAL assessment-results from their own authenticated endpoint, as they hold attestation logs.
+	if oscalAddr != "0" {
+		if err := mgr.Add(&oscal.Server{Addr: oscalAddr, CertDir: oscalCertDir, Client: mgr.GetClient()}); err != nil {
+			setupLog.Error(err, "unable to set up OSCAL assessment-results export")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -0,0 +1,58 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// monitoring generates the Grafana dashboard and the PrometheusRule of the operator
+// from the metric definitions of internal/metrics.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ContainerSolutions/argus/operator/internal/monitoring"
+)
+
+func main() {
+	var dashboard, rules string
+	var target float64
+	flag.StringVar(&dashboard, "dashboard", "config/grafana/argus-compliance.json", "Path to write the Grafana dashboard to.")
+	flag.StringVar(&rules, "rules", "config/prometheus/rules.yaml", "Path to write the PrometheusRule to.")
+	flag.Float64Var(&target, "slo", monitoring.DefaultSLOTarget, "Share of applicable Controls a Component is expected to implement.")
+	flag.Parse()
+
+	err := generate(dashboard, rules, target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(dashboard, rules string, target float64) error {
+	d, err := monitoring.DashboardJSON(target)
+	if err != nil {
+		return fmt.Errorf("could not generate dashboard: %w", err)
+	}
+	err = os.WriteFile(dashboard, d, 0o644)
+	if err != nil {
+		return err
+	}
+	r, err := monitoring.PrometheusRule(target)
+	if err != nil {
+		return fmt.Errorf("could not generate rules: %w", err)
+	}
+	return os.WriteFile(rules, r, 0o644)
+}
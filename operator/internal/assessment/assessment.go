@@ -3,9 +3,13 @@ package assessment
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/componentassessment"
+	"github.com/ContainerSolutions/argus/operator/internal/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +31,37 @@ func GetComponentAssessments(ctx context.Context, cl client.Client, res *argusio
 
 func BuildComponentAssessmentList(ctx context.Context, res *argusiov1alpha1.Assessment, Components []argusiov1alpha1.Component) (map[string]argusiov1alpha1.ComponentAssessment, error) {
 	items := map[string]argusiov1alpha1.ComponentAssessment{}
+	targets, err := TargetComponents(res, Components)
+	if err != nil {
+		return nil, err
+	}
+	for _, Component := range targets {
+		resImp := argusiov1alpha1.ComponentAssessment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%v-%v", res.Name, Component.Name),
+				Namespace: res.Namespace,
+			},
+		}
+		items[resImp.Name] = resImp
+	}
+	return items, nil
+}
+
+// TargetComponents returns the Components an Assessment applies to, in the order they are listed.
+// A Component is targeted when it is referenced in ComponentRef, or when it lives in the Assessment namespace,
+// matches ComponentSelector and has one of ComponentClasses, if any are set.
+// With the Cascade policy, the children of every targeted Component are targeted as well.
+// Children are read from the status of the Component and from the parents of the other Components,
+// as the status only lists a new child once the child was reconciled.
+func TargetComponents(res *argusiov1alpha1.Assessment, Components []argusiov1alpha1.Component) ([]argusiov1alpha1.Component, error) {
+	selector := labels.Nothing()
+	if res.Spec.ComponentSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(res.Spec.ComponentSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid componentSelector: %w", err)
+		}
+	}
 	// Treat Cascading policy. In order to do that, we need to add every child which this Assessment targets.
 	ComponentNameList := []string{}
 	ComponentMap := make(map[string]argusiov1alpha1.Component)
@@ -34,32 +69,88 @@ func BuildComponentAssessmentList(ctx context.Context, res *argusiov1alpha1.Asse
 		ComponentMap[Component.Name] = Component
 	}
 	for _, Component := range Components {
-		for _, refs := range res.Spec.ComponentRef {
-			// If this Component is actually targetted by this Assessment
-			if refs.Name == Component.Name && refs.Namespace == Component.Namespace {
-				ComponentNameList = append(ComponentNameList, Component.Name)
-				if res.Spec.CascadePolicy == argusiov1alpha1.CascadingPolicyCascade {
-					for childName := range Component.Status.Children {
-						// Add children
-						ComponentNameList = append(ComponentNameList, childName)
-					}
-				}
-			}
-
+		if !isReferenced(res, &Component) && !isSelected(res, selector, &Component) {
+			continue
+		}
+		ComponentNameList = append(ComponentNameList, Component.Name)
+		if res.Spec.CascadePolicy == argusiov1alpha1.CascadingPolicyCascade {
+			// Add children
+			ComponentNameList = append(ComponentNameList, childrenOf(&Component, Components)...)
 		}
 	}
+	targets := []argusiov1alpha1.Component{}
+	seen := map[string]bool{}
 	for _, ComponentName := range ComponentNameList {
-		Component := ComponentMap[ComponentName]
-		resImp := argusiov1alpha1.ComponentAssessment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%v-%v", res.Name, Component.Name),
-				Namespace: res.Namespace,
-			},
+		Component, ok := ComponentMap[ComponentName]
+		if !ok || seen[ComponentName] {
+			continue
 		}
-		items[resImp.Name] = resImp
+		seen[ComponentName] = true
+		targets = append(targets, Component)
 	}
-	return items, nil
+	return targets, nil
+}
+
+// childrenOf returns the sorted names of the children of a Component.
+func childrenOf(Component *argusiov1alpha1.Component, Components []argusiov1alpha1.Component) []string {
+	children := utils.SortedKeys(Component.Status.Children)
+	for _, other := range Components {
+		if other.Namespace == Component.Namespace && utils.Contains(other.Spec.Parents, Component.Name) && !utils.Contains(children, other.Name) {
+			children = append(children, other.Name)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+func isReferenced(res *argusiov1alpha1.Assessment, Component *argusiov1alpha1.Component) bool {
+	for _, refs := range res.Spec.ComponentRef {
+		if refs.Name == Component.Name && refs.Namespace == Component.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// referencesParent reports whether an Assessment references one of the parents of a Component.
+func referencesParent(res *argusiov1alpha1.Assessment, Component *argusiov1alpha1.Component) bool {
+	for _, refs := range res.Spec.ComponentRef {
+		if refs.Namespace == Component.Namespace && utils.Contains(Component.Spec.Parents, refs.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSelected(res *argusiov1alpha1.Assessment, selector labels.Selector, Component *argusiov1alpha1.Component) bool {
+	if Component.Namespace != res.Namespace || !selector.Matches(labels.Set(Component.Labels)) {
+		return false
+	}
+	return len(res.Spec.ComponentClasses) == 0 || utils.ContainsOne(res.Spec.ComponentClasses, Component.Spec.Classes)
+}
+
+// AssessmentsForComponent returns the Assessments that may target a Component, either
+// by referencing it or through a ComponentSelector in its namespace. Selector based Assessments
+// are returned whether they match or not, so that relabelled Components are also dropped.
+// Cascade Assessments referencing one of its parents are returned too, as they target it as a child.
+func AssessmentsForComponent(ctx context.Context, cl client.Client, Component *argusiov1alpha1.Component) ([]argusiov1alpha1.NamespacedName, error) {
+	AssessmentList := argusiov1alpha1.AssessmentList{}
+	err := cl.List(ctx, &AssessmentList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Assessments: %w", err)
+	}
+	all := []argusiov1alpha1.NamespacedName{}
+	for i := range AssessmentList.Items {
+		res := &AssessmentList.Items[i]
+		selects := res.Spec.ComponentSelector != nil && res.Namespace == Component.Namespace
+		cascades := res.Spec.CascadePolicy == argusiov1alpha1.CascadingPolicyCascade && referencesParent(res, Component)
+		if selects || cascades || isReferenced(res, Component) {
+			all = append(all, argusiov1alpha1.NamespacedName{Name: res.Name, Namespace: res.Namespace})
+		}
+	}
+	return all, nil
 }
+
 func LifecycleComponentAssessments(ctx context.Context, cl client.Client, new, old map[string]argusiov1alpha1.ComponentAssessment) error {
 	for name := range old {
 		if _, ok := new[name]; !ok {
@@ -75,29 +166,11 @@ func LifecycleComponentAssessments(ctx context.Context, cl client.Client, new, o
 
 func CreateOrUpdateComponentAssessments(ctx context.Context, cl client.Client, scheme *runtime.Scheme, res *argusiov1alpha1.Assessment, Components []argusiov1alpha1.Component) ([]argusiov1alpha1.NamespacedName, error) {
 	all := []argusiov1alpha1.NamespacedName{}
-	// Treat Cascading policy. In order to do that, we need to add every child which this Assessment targets.
-	ComponentNameList := []string{}
-	ComponentMap := make(map[string]argusiov1alpha1.Component)
-	for _, Component := range Components {
-		ComponentMap[Component.Name] = Component
-	}
-	for _, Component := range Components {
-		for _, refs := range res.Spec.ComponentRef {
-			// If this Component is actually targetted by this Assessment
-			if refs.Name == Component.Name && refs.Namespace == Component.Namespace {
-				ComponentNameList = append(ComponentNameList, Component.Name)
-				if res.Spec.CascadePolicy == argusiov1alpha1.CascadingPolicyCascade {
-					for childName := range Component.Status.Children {
-						// Add children
-						ComponentNameList = append(ComponentNameList, childName)
-					}
-				}
-			}
-
-		}
+	targets, err := TargetComponents(res, Components)
+	if err != nil {
+		return nil, err
 	}
-	for _, ComponentName := range ComponentNameList {
-		Component := ComponentMap[ComponentName]
+	for _, Component := range targets {
 		resImp := &argusiov1alpha1.ComponentAssessment{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      fmt.Sprintf("%v-%v", res.Name, Component.Name),
@@ -114,7 +187,7 @@ func CreateOrUpdateComponentAssessments(ctx context.Context, cl client.Client, s
 			}
 			return nil
 		}
-		err := controllerutil.SetControllerReference(res, &resImp.ObjectMeta, scheme)
+		err = controllerutil.SetControllerReference(res, &resImp.ObjectMeta, scheme)
 		if err != nil {
 			return nil, fmt.Errorf("could not set controller reference for ComponentAssessment '%v': %w", resImp.Name, err)
 		}
@@ -130,3 +203,49 @@ func CreateOrUpdateComponentAssessments(ctx context.Context, cl client.Client, s
 	}
 	return all, nil
 }
+
+// CountAttested returns how many of the current ComponentAssessments are attested,
+// leaving out the ones no longer targeted by the Assessment.
+func CountAttested(current, targeted map[string]argusiov1alpha1.ComponentAssessment) int {
+	attested := 0
+	for name, item := range current {
+		if _, ok := targeted[name]; ok && componentassessment.Attested(item) {
+			attested = attested + 1
+		}
+	}
+	return attested
+}
+
+// Default sets the fields of an Assessment that were left empty.
+func Default(res *argusiov1alpha1.Assessment) {
+	if res.Spec.CascadePolicy == "" {
+		res.Spec.CascadePolicy = argusiov1alpha1.CascadingPolicyCascade
+	}
+}
+
+// Validate checks that an Assessment has a known cascade policy and a valid componentSelector,
+// and that a Control with the code and version of its controlRef exists.
+func Validate(ctx context.Context, cl client.Reader, res *argusiov1alpha1.Assessment) error {
+	switch res.Spec.CascadePolicy {
+	case argusiov1alpha1.CascadingPolicyCascade, argusiov1alpha1.CascadingPolicyNone:
+	default:
+		return fmt.Errorf("cascadePolicy must be one of '%v' or '%v', got '%v'", argusiov1alpha1.CascadingPolicyCascade, argusiov1alpha1.CascadingPolicyNone, res.Spec.CascadePolicy)
+	}
+	if res.Spec.ComponentSelector != nil {
+		_, err := metav1.LabelSelectorAsSelector(res.Spec.ComponentSelector)
+		if err != nil {
+			return fmt.Errorf("invalid componentSelector: %w", err)
+		}
+	}
+	ControlList := argusiov1alpha1.ControlList{}
+	err := cl.List(ctx, &ControlList)
+	if err != nil {
+		return fmt.Errorf("could not list Controls: %w", err)
+	}
+	for _, Control := range ControlList.Items {
+		if Control.Spec.Definition.Code == res.Spec.ControlRef.Code && Control.Spec.Definition.Version == res.Spec.ControlRef.Version {
+			return nil
+		}
+	}
+	return fmt.Errorf("controlRef: no Control with code '%v' and version '%v'", res.Spec.ControlRef.Code, res.Spec.ControlRef.Version)
+}
@@ -184,6 +184,258 @@ func TestCreateOrUpdateComponentAssessments(t *testing.T) {
 	}
 }
 
+func TestTargetComponents(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "vm"}}
+	testCases := []struct {
+		name          string
+		Assessment    *argusiov1alpha1.Assessment
+		Components    []argusiov1alpha1.Component
+		expectedNames []string
+		expectedError string
+	}{
+		{
+			name:          "by reference",
+			Assessment:    makeAssessment(),
+			Components:    []argusiov1alpha1.Component{*makeComponent(), *makeComponent(WithName("other"))},
+			expectedNames: []string{"Component"},
+		},
+		{
+			name:       "by selector",
+			Assessment: makeAssessment(WithComponentRef(), WithComponentSelector(selector)),
+			Components: []argusiov1alpha1.Component{
+				*makeComponent(WithComponentLabels(map[string]string{"tier": "vm"})),
+				*makeComponent(WithName("other"), WithComponentLabels(map[string]string{"tier": "db"})),
+				*makeComponent(WithName("unlabelled")),
+				*makeComponent(WithName("vm2"), WithComponentLabels(map[string]string{"tier": "vm"})),
+			},
+			expectedNames: []string{"Component", "vm2"},
+		},
+		{
+			name:       "selector ignores other namespaces",
+			Assessment: makeAssessment(WithComponentRef(), WithComponentSelector(selector)),
+			Components: []argusiov1alpha1.Component{
+				*makeComponent(WithComponentLabels(map[string]string{"tier": "vm"}), WithComponentNamespace("other")),
+			},
+			expectedNames: []string{},
+		},
+		{
+			name:       "selector with classes",
+			Assessment: makeAssessment(WithComponentRef(), WithComponentSelector(selector), WithComponentClasses("two", "three")),
+			Components: []argusiov1alpha1.Component{
+				*makeComponent(WithComponentLabels(map[string]string{"tier": "vm"})),
+				*makeComponent(WithName("vm2"), WithComponentLabels(map[string]string{"tier": "vm"}), WithClasses("four")),
+			},
+			expectedNames: []string{"Component"},
+		},
+		{
+			name:          "empty selector matches everything",
+			Assessment:    makeAssessment(WithComponentRef(), WithComponentSelector(&metav1.LabelSelector{})),
+			Components:    []argusiov1alpha1.Component{*makeComponent(), *makeComponent(WithName("other"))},
+			expectedNames: []string{"Component", "other"},
+		},
+		{
+			name:       "reference and selector are merged",
+			Assessment: makeAssessment(WithComponentSelector(selector)),
+			Components: []argusiov1alpha1.Component{
+				*makeComponent(WithComponentLabels(map[string]string{"tier": "vm"})),
+				*makeComponent(WithName("vm2"), WithComponentLabels(map[string]string{"tier": "vm"})),
+			},
+			expectedNames: []string{"Component", "vm2"},
+		},
+		{
+			name:       "cascading selected Components",
+			Assessment: makeAssessment(WithComponentRef(), WithComponentSelector(selector), WithCascadePolicy(argusiov1alpha1.CascadingPolicyCascade)),
+			Components: []argusiov1alpha1.Component{
+				*makeComponent(WithComponentLabels(map[string]string{"tier": "vm"})),
+				*makeComponent(WithName("child")),
+			},
+			expectedNames: []string{"Component", "child"},
+		},
+		{
+			name:       "cascading to children missing from the status",
+			Assessment: makeAssessment(WithCascadePolicy(argusiov1alpha1.CascadingPolicyCascade)),
+			Components: []argusiov1alpha1.Component{
+				*makeComponent(),
+				*makeComponent(WithName("new"), WithParents("Component")),
+				*makeComponent(WithName("elsewhere"), WithParents("Component"), WithComponentNamespace("other")),
+				*makeComponent(WithName("child")),
+			},
+			expectedNames: []string{"Component", "child", "new"},
+		},
+		{
+			name: "invalid selector",
+			Assessment: makeAssessment(WithComponentSelector(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Bogus"}},
+			})),
+			Components:    []argusiov1alpha1.Component{*makeComponent()},
+			expectedError: "invalid componentSelector",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			output, err := TargetComponents(testCase.Assessment, testCase.Components)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				names := []string{}
+				for _, Component := range output {
+					names = append(names, Component.Name)
+				}
+				assert.Equal(t, testCase.expectedNames, names)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestAssessmentsForComponent(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "vm"}}
+	testCases := []struct {
+		name           string
+		Component      *argusiov1alpha1.Component
+		expectedOutput []argusiov1alpha1.NamespacedName
+		expectedError  string
+		cl             client.Client
+	}{
+		{
+			name:          "fail listing",
+			cl:            fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+			Component:     makeComponent(),
+			expectedError: "could not list Assessments",
+		},
+		{
+			name: "referencing and selecting Assessments",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+				makeAssessment(),
+				makeAssessment(WithAssessmentName("selector"), WithComponentRef(), WithComponentSelector(selector)),
+				makeAssessment(WithAssessmentName("elsewhere"), WithComponentRef(), WithComponentSelector(selector), WithAssessmentNamespace("other")),
+				makeAssessment(WithAssessmentName("unrelated"), WithComponentRef()),
+			).Build(),
+			Component: makeComponent(),
+			expectedOutput: []argusiov1alpha1.NamespacedName{
+				{Name: "selector", Namespace: "test"},
+				{Name: "test", Namespace: "test"},
+			},
+		},
+		{
+			name: "cascading Assessments of parents",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+				makeAssessment(WithAssessmentName("cascade"), WithComponentRef(argusiov1alpha1.NamespacedName{Name: "parent", Namespace: "test"}),
+					WithCascadePolicy(argusiov1alpha1.CascadingPolicyCascade)),
+				makeAssessment(WithAssessmentName("none"), WithComponentRef(argusiov1alpha1.NamespacedName{Name: "parent", Namespace: "test"}),
+					WithCascadePolicy(argusiov1alpha1.CascadingPolicyNone)),
+				makeAssessment(WithAssessmentName("elsewhere"), WithComponentRef(argusiov1alpha1.NamespacedName{Name: "parent", Namespace: "other"}),
+					WithCascadePolicy(argusiov1alpha1.CascadingPolicyCascade)),
+			).Build(),
+			Component: makeComponent(),
+			expectedOutput: []argusiov1alpha1.NamespacedName{
+				{Name: "cascade", Namespace: "test"},
+			},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			output, err := AssessmentsForComponent(context.Background(), testCase.cl, testCase.Component)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedOutput, output)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestCountAttested(t *testing.T) {
+	attested := *makeComponentAssessment()
+	failed := *makeComponentAssessment(func(res *argusiov1alpha1.ComponentAssessment) {
+		res.Status.PassedAttestations = 1
+	})
+	current := map[string]argusiov1alpha1.ComponentAssessment{
+		"attested": attested,
+		"failed":   failed,
+		"dropped":  attested,
+	}
+	targeted := map[string]argusiov1alpha1.ComponentAssessment{
+		"attested": {},
+		"failed":   {},
+		"new":      {},
+	}
+	assert.Equal(t, 1, CountAttested(current, targeted))
+}
+
+func TestDefault(t *testing.T) {
+	res := makeAssessment()
+	Default(res)
+	assert.Equal(t, argusiov1alpha1.CascadingPolicyCascade, res.Spec.CascadePolicy)
+	res = makeAssessment(WithCascadePolicy(argusiov1alpha1.CascadingPolicyNone))
+	Default(res)
+	assert.Equal(t, argusiov1alpha1.CascadingPolicyNone, res.Spec.CascadePolicy)
+}
+
+func TestValidate(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.Assessment
+		cl            client.Client
+		expectedError string
+	}{
+		{
+			name: "valid",
+			res:  makeAssessment(WithCascadePolicy(argusiov1alpha1.CascadingPolicyCascade)),
+			cl:   fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeControl("foo", "v1")).Build(),
+		},
+		{
+			name:          "unknown cascade policy",
+			res:           makeAssessment(WithCascadePolicy("Sometimes")),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeControl("foo", "v1")).Build(),
+			expectedError: "cascadePolicy must be one of 'Cascade' or 'None', got 'Sometimes'",
+		},
+		{
+			name: "invalid selector",
+			res: makeAssessment(WithCascadePolicy(argusiov1alpha1.CascadingPolicyNone), WithComponentSelector(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+			})),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeControl("foo", "v1")).Build(),
+			expectedError: "invalid componentSelector",
+		},
+		{
+			name:          "dangling controlRef",
+			res:           makeAssessment(WithCascadePolicy(argusiov1alpha1.CascadingPolicyNone)),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeControl("foo", "v2")).Build(),
+			expectedError: "controlRef: no Control with code 'foo' and version 'v1'",
+		},
+		{
+			name:          "failed listing",
+			res:           makeAssessment(WithCascadePolicy(argusiov1alpha1.CascadingPolicyNone)),
+			cl:            fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+			expectedError: "could not list Controls",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(context.Background(), testCase.cl, testCase.res)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
 // Helpers
 
 type ComponentAssessmentMutationFn func(*argusiov1alpha1.ComponentAssessment)
@@ -235,6 +487,36 @@ func WithCascadePolicy(policy argusiov1alpha1.AssessmentCascadePolicy) Assessmen
 		res.Spec.CascadePolicy = policy
 	}
 }
+func WithAssessmentName(name string) AssessmentMutationFn {
+	return func(res *argusiov1alpha1.Assessment) {
+		res.ObjectMeta.Name = name
+	}
+}
+
+func WithAssessmentNamespace(namespace string) AssessmentMutationFn {
+	return func(res *argusiov1alpha1.Assessment) {
+		res.ObjectMeta.Namespace = namespace
+	}
+}
+
+func WithComponentRef(refs ...argusiov1alpha1.NamespacedName) AssessmentMutationFn {
+	return func(res *argusiov1alpha1.Assessment) {
+		res.Spec.ComponentRef = refs
+	}
+}
+
+func WithComponentSelector(selector *metav1.LabelSelector) AssessmentMutationFn {
+	return func(res *argusiov1alpha1.Assessment) {
+		res.Spec.ComponentSelector = selector
+	}
+}
+
+func WithComponentClasses(classes ...string) AssessmentMutationFn {
+	return func(res *argusiov1alpha1.Assessment) {
+		res.Spec.ComponentClasses = classes
+	}
+}
+
 func makeAssessment(f ...AssessmentMutationFn) *argusiov1alpha1.Assessment {
 	res := &argusiov1alpha1.Assessment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -272,6 +554,30 @@ func WithName(name string) ComponentMutationFn {
 		res.ObjectMeta.Name = name
 	}
 }
+func WithComponentNamespace(namespace string) ComponentMutationFn {
+	return func(res *argusiov1alpha1.Component) {
+		res.ObjectMeta.Namespace = namespace
+	}
+}
+
+func WithComponentLabels(labels map[string]string) ComponentMutationFn {
+	return func(res *argusiov1alpha1.Component) {
+		res.ObjectMeta.Labels = labels
+	}
+}
+
+func WithParents(parents ...string) ComponentMutationFn {
+	return func(res *argusiov1alpha1.Component) {
+		res.Spec.Parents = parents
+	}
+}
+
+func WithClasses(classes ...string) ComponentMutationFn {
+	return func(res *argusiov1alpha1.Component) {
+		res.Spec.Classes = classes
+	}
+}
+
 func makeComponent(f ...ComponentMutationFn) *argusiov1alpha1.Component {
 	res := &argusiov1alpha1.Component{
 		ObjectMeta: metav1.ObjectMeta{
@@ -299,3 +605,22 @@ func makeComponent(f ...ComponentMutationFn) *argusiov1alpha1.Component {
 	}
 	return res
 }
+
+func makeControl(code, version string) *argusiov1alpha1.Control {
+	return &argusiov1alpha1.Control{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      code + "-" + version,
+			Namespace: "controls",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Control",
+			APIVersion: "argus.io/v1alpha1",
+		},
+		Spec: argusiov1alpha1.ControlSpec{
+			Definition: argusiov1alpha1.ControlDefinition{
+				Code:    code,
+				Version: version,
+			},
+		},
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/schedule"
 	"github.com/ContainerSolutions/argus/operator/internal/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -88,6 +91,7 @@ func CreateOrUpdateComponentAttestations(ctx context.Context, cl client.Client,
 			}
 			emptyMutation := func() error {
 				resAtt.Spec.ProviderRef = res.Spec.ProviderRef
+				resAtt.Spec.Schedule = res.Spec.Schedule
 				resAtt.ObjectMeta.Labels = map[string]string{
 					"argus.io/Assessment":  res.Spec.AssessmentRef,
 					"argus.io/attestation": res.Name,
@@ -109,3 +113,56 @@ func CreateOrUpdateComponentAttestations(ctx context.Context, cl client.Client,
 	}
 	return all, nil
 }
+
+// AttestationsForAssessment returns the Attestations referencing an Assessment.
+func AttestationsForAssessment(ctx context.Context, cl client.Client, AssessmentName string) ([]argusiov1alpha1.NamespacedName, error) {
+	AttestationList := argusiov1alpha1.AttestationList{}
+	err := cl.List(ctx, &AttestationList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Attestations: %w", err)
+	}
+	all := []argusiov1alpha1.NamespacedName{}
+	for _, item := range AttestationList.Items {
+		if item.Spec.AssessmentRef == AssessmentName {
+			all = append(all, argusiov1alpha1.NamespacedName{Name: item.Name, Namespace: item.Namespace})
+		}
+	}
+	return all, nil
+}
+
+// CountPassed returns how many of the ComponentAttestations passed.
+func CountPassed(items map[string]argusiov1alpha1.ComponentAttestation) int {
+	passed := 0
+	for _, item := range items {
+		if item.Status.Result.Result == argusiov1alpha1.AttestationResultTypePass {
+			passed = passed + 1
+		}
+	}
+	return passed
+}
+
+// Validate checks that the Assessment and the AttestationProvider an Attestation references exist,
+// and that its schedule can be parsed. The Assessment is looked up in the Attestation namespace.
+func Validate(ctx context.Context, cl client.Reader, res *argusiov1alpha1.Attestation) error {
+	if res.Spec.Schedule != "" {
+		_, err := schedule.Parse(res.Spec.Schedule)
+		if err != nil {
+			return err
+		}
+	}
+	Assessment := argusiov1alpha1.Assessment{}
+	err := cl.Get(ctx, types.NamespacedName{Name: res.Spec.AssessmentRef, Namespace: res.Namespace}, &Assessment)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("assessmentRef: Assessment '%v' does not exist", res.Spec.AssessmentRef)
+	} else if err != nil {
+		return fmt.Errorf("could not get Assessment '%v': %w", res.Spec.AssessmentRef, err)
+	}
+	provider := argusiov1alpha1.AttestationProvider{}
+	err = cl.Get(ctx, types.NamespacedName{Name: res.Spec.ProviderRef.Name, Namespace: res.Spec.ProviderRef.Namespace}, &provider)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("providerRef: AttestationProvider '%v/%v' does not exist", res.Spec.ProviderRef.Namespace, res.Spec.ProviderRef.Name)
+	} else if err != nil {
+		return fmt.Errorf("could not get AttestationProvider '%v': %w", res.Spec.ProviderRef.Name, err)
+	}
+	return nil
+}
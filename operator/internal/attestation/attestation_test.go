@@ -220,6 +220,113 @@ func TestCreateOrUpdateComponentAttestations(t *testing.T) {
 	}
 }
 
+func TestAttestationsForAssessment(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	testCases := []struct {
+		name           string
+		Assessment     string
+		expectedOutput []argusiov1alpha1.NamespacedName
+		expectedError  string
+		cl             client.Client
+	}{
+		{
+			name:          "fail listing",
+			Assessment:    "Assessment",
+			cl:            fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+			expectedError: "could not list Attestations",
+		},
+		{
+			name:       "referencing Attestations",
+			Assessment: "Assessment",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+				makeAttestation(),
+				makeAttestation(func(res *argusiov1alpha1.Attestation) {
+					res.Name = "other"
+					res.Spec.AssessmentRef = "other"
+				}),
+			).Build(),
+			expectedOutput: []argusiov1alpha1.NamespacedName{
+				{Name: "test", Namespace: "default"},
+			},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			output, err := AttestationsForAssessment(context.Background(), testCase.cl, testCase.Assessment)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedOutput, output)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	Assessment := &argusiov1alpha1.Assessment{ObjectMeta: metav1.ObjectMeta{Name: "Assessment", Namespace: "default"}}
+	provider := &argusiov1alpha1.AttestationProvider{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "bing"}}
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.Attestation
+		cl            client.Client
+		expectedError string
+	}{
+		{
+			name: "valid",
+			res:  makeAttestation(),
+			cl:   fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(Assessment, provider).Build(),
+		},
+		{
+			name:          "dangling assessmentRef",
+			res:           makeAttestation(),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(provider).Build(),
+			expectedError: "assessmentRef: Assessment 'Assessment' does not exist",
+		},
+		{
+			name: "Assessment in another namespace",
+			res: makeAttestation(func(a *argusiov1alpha1.Attestation) {
+				a.Namespace = "other"
+			}),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(Assessment, provider).Build(),
+			expectedError: "assessmentRef: Assessment 'Assessment' does not exist",
+		},
+		{
+			name:          "dangling providerRef",
+			res:           makeAttestation(),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(Assessment).Build(),
+			expectedError: "providerRef: AttestationProvider 'bing/bar' does not exist",
+		},
+		{
+			name: "invalid schedule",
+			res: makeAttestation(func(a *argusiov1alpha1.Attestation) {
+				a.Spec.Schedule = "-5m"
+			}),
+			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(Assessment, provider).Build(),
+			expectedError: "interval must be positive",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(context.Background(), testCase.cl, testCase.res)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
 type attestationMutationFn func(*argusiov1alpha1.Attestation)
 
 func makeAttestation(f ...attestationMutationFn) *argusiov1alpha1.Attestation {
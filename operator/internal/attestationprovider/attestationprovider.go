@@ -0,0 +1,75 @@
+package attestationprovider
+
+import (
+	"context"
+	"fmt"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/provider"
+	"github.com/ContainerSolutions/argus/operator/internal/provider/schema"
+	"github.com/ContainerSolutions/argus/operator/internal/schedule"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Validate checks that the provider type exists, that its providerConfig matches the schema
+// of the type and is accepted by the provider, and that its schedule can be parsed.
+func Validate(res *argusiov1alpha1.AttestationProvider) error {
+	prov, err := provider.GetProvider(res.Spec.Type)
+	if err != nil {
+		return err
+	}
+	err = schema.ValidateConfig(prov.ConfigSchema(), res.Spec.ProviderConfig)
+	if err != nil {
+		return err
+	}
+	if res.Spec.Schedule != "" {
+		_, err = schedule.Parse(res.Spec.Schedule)
+		if err != nil {
+			return err
+		}
+	}
+	c, err := prov.New(res.Name, &res.Spec)
+	if err != nil {
+		return fmt.Errorf("provider '%v' rejected its providerConfig: %w", res.Spec.Type, err)
+	}
+	return c.Close()
+}
+
+// GetConsumers returns the ComponentAttestations that run with the provider.
+func GetConsumers(ctx context.Context, cl client.Client, res *argusiov1alpha1.AttestationProvider) ([]argusiov1alpha1.ComponentAttestation, error) {
+	ComponentAttestationList := argusiov1alpha1.ComponentAttestationList{}
+	err := cl.List(ctx, &ComponentAttestationList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list ComponentAttestations: %w", err)
+	}
+	consumers := []argusiov1alpha1.ComponentAttestation{}
+	for _, item := range ComponentAttestationList.Items {
+		if item.Spec.ProviderRef.Name == res.Name && item.Spec.ProviderRef.Namespace == res.Namespace {
+			consumers = append(consumers, item)
+		}
+	}
+	return consumers, nil
+}
+
+// Health returns how many ComponentAttestations have a provider error, along with the most recent
+// of those errors and when it happened. The time is nil when none of them have one.
+func Health(items []argusiov1alpha1.ComponentAttestation) (int, string, *metav1.Time) {
+	failing := 0
+	lastError := ""
+	var lastErrorTime *metav1.Time
+	for _, item := range items {
+		cond := meta.FindStatusCondition(item.Status.Conditions, argusiov1alpha1.ConditionTypeProviderError)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		failing++
+		if lastErrorTime == nil || lastErrorTime.Before(&cond.LastTransitionTime) {
+			t := cond.LastTransitionTime
+			lastErrorTime = &t
+			lastError = fmt.Sprintf("%v: %v", item.Name, cond.Message)
+		}
+	}
+	return failing, lastError, lastErrorTime
+}
@@ -0,0 +1,228 @@
+package attestationprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.AttestationProvider
+		expectedError string
+	}{
+		{
+			name: "valid",
+			res:  makeAttestationProvider(),
+		},
+		{
+			name:          "unknown type",
+			res:           makeAttestationProvider(WithType("mack")),
+			expectedError: "provider 'mack' doesn't exist",
+		},
+		{
+			name:          "missing required key",
+			res:           makeAttestationProvider(WithType("file"), WithConfig(map[string]string{"positiveRegexp": "ok"})),
+			expectedError: "missing required providerConfig keys: url",
+		},
+		{
+			name:          "unknown key",
+			res:           makeAttestationProvider(WithConfig(map[string]string{"cmd": "true", "expectedStatus": "0"})),
+			expectedError: "unknown providerConfig keys: expectedStatus",
+		},
+		{
+			name:          "rejected by the provider",
+			res:           makeAttestationProvider(WithType("file"), WithConfig(map[string]string{"url": "http://example.com", "positiveRegexp": "ok", "minPositiveMatches": "one"})),
+			expectedError: "provider 'file' rejected its providerConfig: expected integer in 'minPositiveMatches'",
+		},
+		{
+			name:          "invalid schedule",
+			res:           makeAttestationProvider(WithSchedule("every day")),
+			expectedError: "invalid schedule \"every day\"",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(testCase.res)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestGetConsumers(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	testCases := []struct {
+		name     string
+		cl       client.Client
+		expected []string
+	}{
+		{
+			name:     "no consumers",
+			cl:       fake.NewClientBuilder().WithScheme(commonScheme).Build(),
+			expected: []string{},
+		},
+		{
+			name: "only matching provider refs",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+				makeComponentAttestation("a"),
+				makeComponentAttestation("b", WithProviderRef("other", "prov")),
+				makeComponentAttestation("c", WithProviderRef("prov", "other")),
+				makeComponentAttestation("d"),
+			).Build(),
+			expected: []string{"a", "d"},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			consumers, err := GetConsumers(context.Background(), testCase.cl, makeAttestationProvider())
+			require.NoError(t, err)
+			names := []string{}
+			for _, c := range consumers {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, testCase.expected, names)
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	testCases := []struct {
+		name              string
+		items             []argusiov1alpha1.ComponentAttestation
+		expectedFailing   int
+		expectedError     string
+		expectedErrorTime *metav1.Time
+	}{
+		{
+			name:  "no attestations",
+			items: []argusiov1alpha1.ComponentAttestation{},
+		},
+		{
+			name: "healthy",
+			items: []argusiov1alpha1.ComponentAttestation{
+				*makeComponentAttestation("a"),
+				*makeComponentAttestation("b", WithProviderError(metav1.ConditionFalse, "", later)),
+			},
+		},
+		{
+			name: "most recent error",
+			items: []argusiov1alpha1.ComponentAttestation{
+				*makeComponentAttestation("a", WithProviderError(metav1.ConditionTrue, "old", earlier)),
+				*makeComponentAttestation("b", WithProviderError(metav1.ConditionTrue, "new", later)),
+				*makeComponentAttestation("c", WithProviderError(metav1.ConditionFalse, "", later)),
+			},
+			expectedFailing:   2,
+			expectedError:     "b: new",
+			expectedErrorTime: &later,
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			failing, lastError, lastErrorTime := Health(testCase.items)
+			assert.Equal(t, testCase.expectedFailing, failing)
+			assert.Equal(t, testCase.expectedError, lastError)
+			assert.Equal(t, testCase.expectedErrorTime, lastErrorTime)
+		})
+	}
+}
+
+type ProvFn func(*argusiov1alpha1.AttestationProvider)
+
+func WithType(t string) ProvFn {
+	return func(p *argusiov1alpha1.AttestationProvider) {
+		p.Spec.Type = t
+	}
+}
+func WithConfig(c map[string]string) ProvFn {
+	return func(p *argusiov1alpha1.AttestationProvider) {
+		p.Spec.ProviderConfig = c
+	}
+}
+func WithSchedule(s string) ProvFn {
+	return func(p *argusiov1alpha1.AttestationProvider) {
+		p.Spec.Schedule = s
+	}
+}
+func makeAttestationProvider(f ...ProvFn) *argusiov1alpha1.AttestationProvider {
+	res := &argusiov1alpha1.AttestationProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prov",
+			Namespace: "prov",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "AttestationProvider",
+			APIVersion: "argus.io/v1alpha1",
+		},
+		Spec: argusiov1alpha1.AttestationProviderSpec{
+			Type:           "command",
+			ProviderConfig: map[string]string{"cmd": "true"},
+		},
+	}
+	for _, m := range f {
+		m(res)
+	}
+	return res
+}
+
+type MutationFn func(*argusiov1alpha1.ComponentAttestation)
+
+func WithProviderRef(name, namespace string) MutationFn {
+	return func(res *argusiov1alpha1.ComponentAttestation) {
+		res.Spec.ProviderRef = argusiov1alpha1.AttestationProviderRef{Name: name, Namespace: namespace}
+	}
+}
+func WithProviderError(status metav1.ConditionStatus, message string, at metav1.Time) MutationFn {
+	return func(res *argusiov1alpha1.ComponentAttestation) {
+		res.Status.Conditions = append(res.Status.Conditions, metav1.Condition{
+			Type:               argusiov1alpha1.ConditionTypeProviderError,
+			Status:             status,
+			Message:            message,
+			LastTransitionTime: at,
+		})
+	}
+}
+func makeComponentAttestation(name string, f ...MutationFn) *argusiov1alpha1.ComponentAttestation {
+	res := &argusiov1alpha1.ComponentAttestation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ComponentAttestation",
+			APIVersion: "argus.io/v1alpha1",
+		},
+		Spec: argusiov1alpha1.ComponentAttestationSpec{
+			ProviderRef: argusiov1alpha1.AttestationProviderRef{
+				Name:      "prov",
+				Namespace: "prov",
+			},
+		},
+	}
+	for _, m := range f {
+		m(res)
+	}
+	return res
+}
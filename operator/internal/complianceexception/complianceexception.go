@@ -0,0 +1,165 @@
+package complianceexception
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Active reports whether an exception has not expired at now.
+func Active(res *argusiov1alpha1.ComplianceException, now time.Time) bool {
+	return now.Before(res.Spec.ExpiresAt.Time)
+}
+
+// Targets reports whether an exception applies to a Component, either because it is listed in
+// ComponentRef, or because it lives in the exception namespace and matches ComponentSelector.
+func Targets(res *argusiov1alpha1.ComplianceException, Component *argusiov1alpha1.Component) (bool, error) {
+	if Component.Namespace != res.Namespace {
+		return false, nil
+	}
+	if utils.Contains(res.Spec.ComponentRef, Component.Name) {
+		return true, nil
+	}
+	if res.Spec.ComponentSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(res.Spec.ComponentSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid componentSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(Component.Labels)), nil
+}
+
+// TargetComponents returns the names of the Components an exception applies to, in the order they are listed.
+func TargetComponents(res *argusiov1alpha1.ComplianceException, Components []argusiov1alpha1.Component) ([]string, error) {
+	targets := []string{}
+	for i := range Components {
+		ok, err := Targets(res, &Components[i])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			targets = append(targets, Components[i].Name)
+		}
+	}
+	return targets, nil
+}
+
+// Waivers holds what the active exceptions waive for a Control on a Component.
+type Waivers struct {
+	// Control is true when an exception waives the whole Control.
+	Control bool
+	// Assessments holds the names of the Assessments whose ComponentAssessments are waived.
+	Assessments map[string]bool
+	// Exceptions lists the exceptions waiving anything.
+	Exceptions []argusiov1alpha1.NamespacedName
+	// Expiry is when the first of the exceptions lapses, if any applies.
+	Expiry *time.Time
+}
+
+// Waives reports whether a ComponentAssessment is waived.
+func (w Waivers) Waives(res argusiov1alpha1.ComponentAssessment) bool {
+	return w.Control || w.Assessments[res.Labels["argus.io/Assessment"]]
+}
+
+// GetWaivers returns what the exceptions active at now waive for the Control with the given code and
+// version, on the named Component. Components are looked up in the namespace of each exception.
+func GetWaivers(ctx context.Context, cl client.Client, ComponentName, code, version string, now time.Time) (Waivers, error) {
+	waivers := Waivers{Assessments: map[string]bool{}}
+	list := argusiov1alpha1.ComplianceExceptionList{}
+	err := cl.List(ctx, &list)
+	if err != nil {
+		return waivers, fmt.Errorf("could not list ComplianceExceptions: %w", err)
+	}
+	for i := range list.Items {
+		res := &list.Items[i]
+		if !Active(res, now) || res.Spec.ControlRef.Code != code || res.Spec.ControlRef.Version != version {
+			continue
+		}
+		Component := argusiov1alpha1.Component{}
+		err := cl.Get(ctx, types.NamespacedName{Name: ComponentName, Namespace: res.Namespace}, &Component)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return waivers, fmt.Errorf("could not get Component '%v': %w", ComponentName, err)
+		}
+		ok, err := Targets(res, &Component)
+		if err != nil {
+			return waivers, fmt.Errorf("ComplianceException '%v': %w", res.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		if res.Spec.AssessmentRef == "" {
+			waivers.Control = true
+		} else {
+			waivers.Assessments[res.Spec.AssessmentRef] = true
+		}
+		waivers.Exceptions = append(waivers.Exceptions, argusiov1alpha1.NamespacedName{Name: res.Name, Namespace: res.Namespace})
+		if waivers.Expiry == nil || res.Spec.ExpiresAt.Time.Before(*waivers.Expiry) {
+			expiry := res.Spec.ExpiresAt.Time
+			waivers.Expiry = &expiry
+		}
+	}
+	return waivers, nil
+}
+
+// Validate checks that an exception targets Components with a valid componentSelector, and that the
+// Control of its controlRef exists, along with the Assessment of its assessmentRef, if any.
+func Validate(ctx context.Context, cl client.Reader, res *argusiov1alpha1.ComplianceException) error {
+	if len(res.Spec.ComponentRef) == 0 && res.Spec.ComponentSelector == nil {
+		return fmt.Errorf("one of componentRef or componentSelector is required")
+	}
+	if res.Spec.ComponentSelector != nil {
+		_, err := metav1.LabelSelectorAsSelector(res.Spec.ComponentSelector)
+		if err != nil {
+			return fmt.Errorf("invalid componentSelector: %w", err)
+		}
+	}
+	ControlList := argusiov1alpha1.ControlList{}
+	err := cl.List(ctx, &ControlList)
+	if err != nil {
+		return fmt.Errorf("could not list Controls: %w", err)
+	}
+	found := false
+	for _, Control := range ControlList.Items {
+		if Control.Spec.Definition.Code == res.Spec.ControlRef.Code && Control.Spec.Definition.Version == res.Spec.ControlRef.Version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("controlRef: no Control with code '%v' and version '%v'", res.Spec.ControlRef.Code, res.Spec.ControlRef.Version)
+	}
+	if res.Spec.AssessmentRef == "" {
+		return nil
+	}
+	Assessment := argusiov1alpha1.Assessment{}
+	err = cl.Get(ctx, types.NamespacedName{Name: res.Spec.AssessmentRef, Namespace: res.Namespace}, &Assessment)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("assessmentRef: Assessment '%v' does not exist", res.Spec.AssessmentRef)
+	} else if err != nil {
+		return fmt.Errorf("could not get Assessment '%v': %w", res.Spec.AssessmentRef, err)
+	}
+	if Assessment.Spec.ControlRef != res.Spec.ControlRef {
+		return fmt.Errorf("assessmentRef: Assessment '%v' assesses Control '%v' version '%v', not the one of controlRef",
+			Assessment.Name, Assessment.Spec.ControlRef.Code, Assessment.Spec.ControlRef.Version)
+	}
+	return nil
+}
+
+// ValidateExpiry checks that an exception expires after now.
+func ValidateExpiry(res *argusiov1alpha1.ComplianceException, now time.Time) error {
+	if !Active(res, now) {
+		return fmt.Errorf("expiresAt must be in the future, got %v", res.Spec.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
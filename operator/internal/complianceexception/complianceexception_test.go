@@ -0,0 +1,355 @@
+package complianceexception
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestActive(t *testing.T) {
+	t.Parallel()
+	assert.True(t, Active(makeException(), now))
+	assert.False(t, Active(makeException(WithExpiry(now)), now))
+	assert.False(t, Active(makeException(WithExpiry(now.Add(-time.Hour))), now))
+}
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.ComplianceException
+		component     *argusiov1alpha1.Component
+		expected      bool
+		expectedError string
+	}{
+		{
+			name:      "referenced Component",
+			res:       makeException(),
+			component: makeComponent("vm"),
+			expected:  true,
+		},
+		{
+			name:      "Component not referenced",
+			res:       makeException(),
+			component: makeComponent("other"),
+			expected:  false,
+		},
+		{
+			name: "referenced Component in another namespace",
+			res:  makeException(),
+			component: makeComponent("vm", func(c *argusiov1alpha1.Component) {
+				c.Namespace = "other"
+			}),
+			expected: false,
+		},
+		{
+			name:      "selected Component",
+			res:       makeException(WithSelector(map[string]string{"env": "dev"})),
+			component: makeComponent("other", WithComponentLabels(map[string]string{"env": "dev"})),
+			expected:  true,
+		},
+		{
+			name:      "Component not selected",
+			res:       makeException(WithSelector(map[string]string{"env": "dev"})),
+			component: makeComponent("other", WithComponentLabels(map[string]string{"env": "prod"})),
+			expected:  false,
+		},
+		{
+			name: "invalid selector",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.ComponentSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Bogus"}}}
+			}),
+			component:     makeComponent("other"),
+			expectedError: "invalid componentSelector",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := Targets(testCase.res, testCase.component)
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, ok)
+		})
+	}
+}
+
+func TestGetWaivers(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	testCases := []struct {
+		name          string
+		cl            client.Client
+		expected      Waivers
+		expectedError string
+	}{
+		{
+			name:     "no exceptions",
+			cl:       fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm")).Build(),
+			expected: Waivers{Assessments: map[string]bool{}},
+		},
+		{
+			name: "Control waived",
+			cl:   fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm"), makeException()).Build(),
+			expected: Waivers{
+				Control:     true,
+				Assessments: map[string]bool{},
+				Exceptions:  []argusiov1alpha1.NamespacedName{{Name: "exception", Namespace: "default"}},
+				Expiry:      timePtr(now.Add(24 * time.Hour)),
+			},
+		},
+		{
+			name: "Assessment waived",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm"), makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.AssessmentRef = "assessment"
+			})).Build(),
+			expected: Waivers{
+				Assessments: map[string]bool{"assessment": true},
+				Exceptions:  []argusiov1alpha1.NamespacedName{{Name: "exception", Namespace: "default"}},
+				Expiry:      timePtr(now.Add(24 * time.Hour)),
+			},
+		},
+		{
+			name: "earliest expiry of several exceptions",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm"), makeException(), makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Name = "sooner"
+				e.Spec.ExpiresAt = metav1.NewTime(now.Add(time.Hour))
+			})).Build(),
+			expected: Waivers{
+				Control:     true,
+				Assessments: map[string]bool{},
+				Exceptions:  []argusiov1alpha1.NamespacedName{{Name: "exception", Namespace: "default"}, {Name: "sooner", Namespace: "default"}},
+				Expiry:      timePtr(now.Add(time.Hour)),
+			},
+		},
+		{
+			name:     "expired exception",
+			cl:       fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm"), makeException(WithExpiry(now.Add(-time.Hour)))).Build(),
+			expected: Waivers{Assessments: map[string]bool{}},
+		},
+		{
+			name: "exception of another Control",
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm"), makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.ControlRef.Version = "2"
+			})).Build(),
+			expected: Waivers{Assessments: map[string]bool{}},
+		},
+		{
+			name:     "exception in a namespace without the Component",
+			cl:       fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeException()).Build(),
+			expected: Waivers{Assessments: map[string]bool{}},
+		},
+		{
+			name:          "error listing",
+			cl:            fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+			expectedError: "could not list ComplianceExceptions",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			waivers, err := GetWaivers(context.Background(), testCase.cl, "vm", "CTRL-01", "1", now)
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			// Times read back from the API server are in the local time zone.
+			if testCase.expected.Expiry != nil {
+				require.NotNil(t, waivers.Expiry)
+				assert.True(t, testCase.expected.Expiry.Equal(*waivers.Expiry))
+				testCase.expected.Expiry, waivers.Expiry = nil, nil
+			}
+			assert.Equal(t, testCase.expected, waivers)
+		})
+	}
+}
+
+func TestWaives(t *testing.T) {
+	t.Parallel()
+	assessment := argusiov1alpha1.ComponentAssessment{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"argus.io/Assessment": "assessment"}}}
+	assert.True(t, Waivers{Control: true}.Waives(assessment))
+	assert.True(t, Waivers{Assessments: map[string]bool{"assessment": true}}.Waives(assessment))
+	assert.False(t, Waivers{Assessments: map[string]bool{"other": true}}.Waives(assessment))
+	assert.False(t, Waivers{}.Waives(assessment))
+}
+
+func TestValidate(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	control := &argusiov1alpha1.Control{
+		ObjectMeta: metav1.ObjectMeta{Name: "control", Namespace: "default"},
+		Spec: argusiov1alpha1.ControlSpec{
+			Definition: argusiov1alpha1.ControlDefinition{Code: "CTRL-01", Version: "1"},
+		},
+	}
+	assessment := &argusiov1alpha1.Assessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "assessment", Namespace: "default"},
+		Spec: argusiov1alpha1.AssessmentSpec{
+			ControlRef: argusiov1alpha1.AssessmentControlDefinition{Code: "CTRL-01", Version: "1"},
+		},
+	}
+	otherAssessment := &argusiov1alpha1.Assessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec: argusiov1alpha1.AssessmentSpec{
+			ControlRef: argusiov1alpha1.AssessmentControlDefinition{Code: "CTRL-02", Version: "1"},
+		},
+	}
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.ComplianceException
+		expectedError string
+	}{
+		{
+			name: "valid",
+			res:  makeException(),
+		},
+		{
+			name: "valid with assessmentRef",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.AssessmentRef = "assessment"
+			}),
+		},
+		{
+			name: "no Components",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.ComponentRef = nil
+			}),
+			expectedError: "one of componentRef or componentSelector is required",
+		},
+		{
+			name: "invalid selector",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.ComponentSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Bogus"}}}
+			}),
+			expectedError: "invalid componentSelector",
+		},
+		{
+			name: "dangling controlRef",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.ControlRef.Version = "2"
+			}),
+			expectedError: "controlRef: no Control with code 'CTRL-01' and version '2'",
+		},
+		{
+			name: "dangling assessmentRef",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.AssessmentRef = "missing"
+			}),
+			expectedError: "assessmentRef: Assessment 'missing' does not exist",
+		},
+		{
+			name: "Assessment of another Control",
+			res: makeException(func(e *argusiov1alpha1.ComplianceException) {
+				e.Spec.AssessmentRef = "other"
+			}),
+			expectedError: "assessmentRef: Assessment 'other' assesses Control 'CTRL-02' version '1', not the one of controlRef",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(control, assessment, otherAssessment).Build()
+			err := Validate(context.Background(), cl, testCase.res)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateExpiry(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, ValidateExpiry(makeException(), now))
+	assert.EqualError(t, ValidateExpiry(makeException(WithExpiry(now.Add(-time.Hour))), now), "expiresAt must be in the future, got 2023-06-01T11:00:00Z")
+}
+
+// Helpers
+
+type exceptionMutationFn func(*argusiov1alpha1.ComplianceException)
+
+func WithExpiry(expiry time.Time) exceptionMutationFn {
+	return func(res *argusiov1alpha1.ComplianceException) {
+		res.Spec.ExpiresAt = metav1.NewTime(expiry)
+	}
+}
+
+func WithSelector(labels map[string]string) exceptionMutationFn {
+	return func(res *argusiov1alpha1.ComplianceException) {
+		res.Spec.ComponentRef = nil
+		res.Spec.ComponentSelector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+}
+
+func makeException(f ...exceptionMutationFn) *argusiov1alpha1.ComplianceException {
+	res := &argusiov1alpha1.ComplianceException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "exception",
+			Namespace: "default",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ComplianceException",
+			APIVersion: "argus.io/v1alpha1",
+		},
+		Spec: argusiov1alpha1.ComplianceExceptionSpec{
+			ControlRef:    argusiov1alpha1.AssessmentControlDefinition{Code: "CTRL-01", Version: "1"},
+			ComponentRef:  []string{"vm"},
+			Justification: "migration in progress",
+			Approver:      "security@example.com",
+			ExpiresAt:     metav1.NewTime(now.Add(24 * time.Hour)),
+		},
+	}
+	for _, fn := range f {
+		fn(res)
+	}
+	return res
+}
+
+type componentMutationFn func(*argusiov1alpha1.Component)
+
+func WithComponentLabels(labels map[string]string) componentMutationFn {
+	return func(res *argusiov1alpha1.Component) {
+		res.Labels = labels
+	}
+}
+
+func makeComponent(name string, f ...componentMutationFn) *argusiov1alpha1.Component {
+	res := &argusiov1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Component",
+			APIVersion: "argus.io/v1alpha1",
+		},
+	}
+	for _, fn := range f {
+		fn(res)
+	}
+	return res
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
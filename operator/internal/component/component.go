@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/componentcontrol"
 	"github.com/ContainerSolutions/argus/operator/internal/metrics"
 	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,13 +16,17 @@ import (
 
 func UpdateControls(ComponentControlList argusiov1alpha1.ComponentControlList, Component *argusiov1alpha1.Component) *argusiov1alpha1.Component {
 	validControls := 0
+	waivedControls := 0
 	reqs := make(map[string]*argusiov1alpha1.ComponentControlCompliance)
 	for _, ComponentControl := range ComponentControlList.Items {
 		status := argusiov1alpha1.ComponentControlCompliance{}
 		status.Implemented = false
-		if (ComponentControl.Status.ValidAssessments == ComponentControl.Status.TotalAssessments) && ComponentControl.Status.TotalAssessments > 0 {
+		if componentcontrol.Implemented(ComponentControl) {
 			status.Implemented = true
 			validControls = validControls + 1
+		} else if ComponentControl.Status.Waived {
+			status.Waived = true
+			waivedControls = waivedControls + 1
 		}
 		name := fmt.Sprintf("%v:%v", ComponentControl.Spec.Definition.Code, ComponentControl.Spec.Definition.Version)
 		reqs[name] = &status
@@ -37,11 +43,13 @@ func UpdateControls(ComponentControlList argusiov1alpha1.ComponentControlList, C
 	Component.Status.CompliantChildren = compliantChildren
 	Component.Status.TotalControls = len(ComponentControlList.Items)
 	Component.Status.ImplementedControls = validControls
+	Component.Status.WaivedControls = waivedControls
 	labels := map[string]string{
 		"Component": Component.Name,
 	}
 	metrics.GetGaugeVec(metrics.ControlTotalKey).With(labels).Set(float64(Component.Status.TotalControls))
 	metrics.GetGaugeVec(metrics.ControlValidKey).With(labels).Set(float64(Component.Status.ImplementedControls))
+	metrics.GetGaugeVec(metrics.ControlWaivedKey).With(labels).Set(float64(Component.Status.WaivedControls))
 	return Component
 }
 
@@ -63,7 +71,7 @@ func UpdateChild(ctx context.Context, cl client.Client, Component *argusiov1alph
 			parentComponent.Status.Children = make(map[string]argusiov1alpha1.ComponentChild)
 		}
 		parentComponent.Status.Children[Component.Name] = argusiov1alpha1.ComponentChild{
-			Compliant: Component.Status.TotalControls == Component.Status.ImplementedControls,
+			Compliant: Compliant(Component),
 		}
 		err = cl.Status().Patch(ctx, &parentComponent, client.MergeFrom(original))
 		if err != nil {
@@ -76,3 +84,27 @@ func UpdateChild(ctx context.Context, cl client.Client, Component *argusiov1alph
 	}
 	return nil
 }
+
+// Compliant reports whether every Control applicable to a Component is either implemented or waived.
+func Compliant(Component *argusiov1alpha1.Component) bool {
+	return Component.Status.TotalControls == Component.Status.ImplementedControls+Component.Status.WaivedControls
+}
+
+// Parents returns the names of the parents of a Component, which track its compliance as a child.
+func Parents(Component *argusiov1alpha1.Component) []argusiov1alpha1.NamespacedName {
+	parents := []argusiov1alpha1.NamespacedName{}
+	for _, parentName := range Component.Spec.Parents {
+		parents = append(parents, argusiov1alpha1.NamespacedName{
+			Name:      parentName,
+			Namespace: Component.Namespace,
+		})
+	}
+	return parents
+}
+
+// StatusChanged reports whether the status of a Component changed from the original one, other than its RunAt.
+func StatusChanged(original, Component *argusiov1alpha1.Component) bool {
+	status := Component.Status.DeepCopy()
+	status.RunAt = original.Status.RunAt
+	return !equality.Semantic.DeepEqual(&original.Status, status)
+}
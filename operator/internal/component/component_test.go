@@ -3,6 +3,7 @@ package component
 import (
 	"context"
 	"testing"
+	"time"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/ContainerSolutions/argus/operator/internal/metrics"
@@ -82,6 +83,56 @@ func TestUpdateControls(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "Waived Component Control",
+			inputComponent: &argusiov1alpha1.Component{},
+			expectedOutput: &argusiov1alpha1.Component{
+				Status: argusiov1alpha1.ComponentStatus{
+					Controls: map[string]*argusiov1alpha1.ComponentControlCompliance{
+						"test:1": {
+							Implemented: true,
+						},
+						"test2:1": {
+							Implemented: false,
+							Waived:      true,
+						},
+					},
+					TotalControls:       2,
+					ImplementedControls: 1,
+					WaivedControls:      1,
+				},
+			},
+			inputComponentControlList: argusiov1alpha1.ComponentControlList{
+				Items: []argusiov1alpha1.ComponentControl{
+					{
+						Spec: argusiov1alpha1.ComponentControlSpec{
+							Definition: argusiov1alpha1.ControlDefinition{
+								Code:    "test",
+								Version: "1",
+							},
+						},
+						Status: argusiov1alpha1.ComponentControlStatus{
+							ValidAssessments: 1,
+							TotalAssessments: 1,
+						},
+					},
+					{
+						Spec: argusiov1alpha1.ComponentControlSpec{
+							Definition: argusiov1alpha1.ControlDefinition{
+								Code:    "test2",
+								Version: "1",
+							},
+						},
+						Status: argusiov1alpha1.ComponentControlStatus{
+							ValidAssessments:  0,
+							TotalAssessments:  1,
+							WaivedAssessments: 1,
+							Waived:            true,
+						},
+					},
+				},
+			},
+		},
 	}
 	for i := range testCases {
 		testCase := testCases[i]
@@ -91,6 +142,8 @@ func TestUpdateControls(t *testing.T) {
 			assert.Equal(t, output.Status.Controls, testCase.expectedOutput.Status.Controls)
 			assert.Equal(t, output.Status.TotalControls, testCase.expectedOutput.Status.TotalControls)
 			assert.Equal(t, output.Status.ImplementedControls, testCase.expectedOutput.Status.ImplementedControls)
+			assert.Equal(t, output.Status.WaivedControls, testCase.expectedOutput.Status.WaivedControls)
+			assert.Equal(t, Compliant(testCase.expectedOutput), Compliant(output))
 		})
 	}
 }
@@ -160,3 +213,88 @@ func TestUpdateChild(t *testing.T) {
 		})
 	}
 }
+
+func TestParents(t *testing.T) {
+	testCases := []struct {
+		name           string
+		inputComponent *argusiov1alpha1.Component
+		expectedOutput []argusiov1alpha1.NamespacedName
+	}{
+		{
+			name:           "No Parents",
+			inputComponent: &argusiov1alpha1.Component{},
+			expectedOutput: []argusiov1alpha1.NamespacedName{},
+		},
+		{
+			name: "Parents in the Component namespace",
+			inputComponent: &argusiov1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: argusiov1alpha1.ComponentSpec{
+					Parents: []string{"one", "two"},
+				},
+			},
+			expectedOutput: []argusiov1alpha1.NamespacedName{
+				{Name: "one", Namespace: "default"},
+				{Name: "two", Namespace: "default"},
+			},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedOutput, Parents(testCase.inputComponent))
+		})
+	}
+}
+
+func TestStatusChanged(t *testing.T) {
+	original := &argusiov1alpha1.Component{
+		Status: argusiov1alpha1.ComponentStatus{
+			RunAt:               metav1.NewTime(metav1.Now().Add(-time.Hour)),
+			TotalControls:       2,
+			ImplementedControls: 1,
+			Controls: map[string]*argusiov1alpha1.ComponentControlCompliance{
+				"foo:v1": {Implemented: true},
+				"bar:v1": {},
+			},
+		},
+	}
+	testCases := []struct {
+		name           string
+		mutate         func(*argusiov1alpha1.Component)
+		expectedOutput bool
+	}{
+		{
+			name:           "Only RunAt changed",
+			mutate:         func(c *argusiov1alpha1.Component) { c.Status.RunAt = metav1.Now() },
+			expectedOutput: false,
+		},
+		{
+			name: "Control implemented",
+			mutate: func(c *argusiov1alpha1.Component) {
+				c.Status.RunAt = metav1.Now()
+				c.Status.ImplementedControls = 2
+				c.Status.Controls["bar:v1"].Implemented = true
+			},
+			expectedOutput: true,
+		},
+		{
+			name: "Child added",
+			mutate: func(c *argusiov1alpha1.Component) {
+				c.Status.Children = map[string]argusiov1alpha1.ComponentChild{"child": {Compliant: true}}
+			},
+			expectedOutput: true,
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			Component := original.DeepCopy()
+			testCase.mutate(Component)
+			assert.Equal(t, testCase.expectedOutput, StatusChanged(original, Component))
+		})
+	}
+}
@@ -40,3 +40,9 @@ func GetValidComponentAttestations(ctx context.Context, attestations []argusiov1
 	}
 	return children, valid
 }
+
+// Attested reports whether every ComponentAttestation of a ComponentAssessment passed.
+// A ComponentAssessment without any ComponentAttestation is not attested.
+func Attested(res argusiov1alpha1.ComponentAssessment) bool {
+	return res.Status.TotalAttestations == res.Status.PassedAttestations && res.Status.TotalAttestations > 0
+}
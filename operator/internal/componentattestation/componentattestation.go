@@ -3,6 +3,7 @@ package componentattestation
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -10,8 +11,24 @@ import (
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/ContainerSolutions/argus/operator/internal/provider"
 	"github.com/ContainerSolutions/argus/operator/internal/provider/schema"
+	"github.com/ContainerSolutions/argus/operator/internal/schedule"
 )
 
+// DefaultAttestationTimeout is used when the AttestationProvider does not declare a timeout.
+const DefaultAttestationTimeout = 5 * time.Minute
+
+// timeoutClient bounds every Attest call with the provider timeout.
+type timeoutClient struct {
+	schema.AttestationClient
+	timeout time.Duration
+}
+
+func (t *timeoutClient) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.AttestationClient.Attest(ctx)
+}
+
 func GetAttestationClient(ctx context.Context, cl client.Client, res *argusiov1alpha1.ComponentAttestation) (schema.AttestationClient, error) {
 	providerSpec := argusiov1alpha1.AttestationProvider{}
 	req := types.NamespacedName{
@@ -30,5 +47,45 @@ func GetAttestationClient(ctx context.Context, cl client.Client, res *argusiov1a
 	if err != nil {
 		return nil, fmt.Errorf("could not instantiate client for provider '%v': %w", req.Name, err)
 	}
-	return attestationClient, nil
+	timeout := DefaultAttestationTimeout
+	if providerSpec.Spec.Timeout != nil && providerSpec.Spec.Timeout.Duration > 0 {
+		timeout = providerSpec.Spec.Timeout.Duration
+	}
+	return &timeoutClient{AttestationClient: attestationClient, timeout: timeout}, nil
+}
+
+// GetSchedule returns the schedule of a ComponentAttestation. If it does not set one, the schedule
+// of its AttestationProvider is used, and if neither do, the attestation runs every interval.
+func GetSchedule(ctx context.Context, cl client.Client, res *argusiov1alpha1.ComponentAttestation, interval time.Duration) (schedule.Schedule, error) {
+	expr := res.Spec.Schedule
+	if expr == "" {
+		providerSpec := argusiov1alpha1.AttestationProvider{}
+		req := types.NamespacedName{
+			Name:      res.Spec.ProviderRef.Name,
+			Namespace: res.Spec.ProviderRef.Namespace,
+		}
+		err := cl.Get(ctx, req, &providerSpec)
+		if err != nil {
+			return nil, fmt.Errorf("could not get provider spec '%v': %w", req.Name, err)
+		}
+		expr = providerSpec.Spec.Schedule
+	}
+	if expr == "" {
+		return schedule.Parse(interval.String())
+	}
+	return schedule.Parse(expr)
+}
+
+// ResultReason returns the condition reason matching an attestation result.
+func ResultReason(result argusiov1alpha1.AttestationResultType) string {
+	switch result {
+	case argusiov1alpha1.AttestationResultTypePass:
+		return argusiov1alpha1.ConditionReasonPassed
+	case argusiov1alpha1.AttestationResultTypeFail:
+		return argusiov1alpha1.ConditionReasonFailed
+	case argusiov1alpha1.AttestationResultTypeNotStarted:
+		return argusiov1alpha1.ConditionReasonNotStarted
+	default:
+		return argusiov1alpha1.ConditionReasonUnknown
+	}
 }
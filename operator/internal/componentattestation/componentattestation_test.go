@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
@@ -17,12 +18,12 @@ import (
 )
 
 type MockClient struct {
-	AttestFn func() (argusiov1alpha1.AttestationResult, error)
+	AttestFn func(context.Context) (argusiov1alpha1.AttestationResult, error)
 	CloseFn  func() error
 }
 
-func (m *MockClient) Attest() (argusiov1alpha1.AttestationResult, error) {
-	return m.AttestFn()
+func (m *MockClient) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
+	return m.AttestFn(ctx)
 }
 
 func (m *MockClient) Close() error {
@@ -37,6 +38,10 @@ func (m *MockProvider) New(_ string, a *argusiov1alpha1.AttestationProviderSpec)
 	return m.NewFn(a)
 }
 
+func (m *MockProvider) ConfigSchema() []schema.ConfigField {
+	return nil
+}
+
 func TestGetAttestationClient(t *testing.T) {
 	commonScheme := runtime.NewScheme()
 	err := argusiov1alpha1.AddToScheme(commonScheme)
@@ -96,6 +101,117 @@ func TestGetAttestationClient(t *testing.T) {
 	}
 }
 
+func TestGetAttestationClientTimeout(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	testCases := []struct {
+		name             string
+		provider         *argusiov1alpha1.AttestationProvider
+		expectedDeadline time.Duration
+	}{
+		{
+			name:             "default timeout",
+			provider:         makeAttestationProvider(),
+			expectedDeadline: DefaultAttestationTimeout,
+		},
+		{
+			name:             "provider timeout",
+			provider:         makeAttestationProvider(WithTimeout(10 * time.Second)),
+			expectedDeadline: 10 * time.Second,
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			var remaining time.Duration
+			mock := &MockClient{
+				AttestFn: func(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
+					deadline, ok := ctx.Deadline()
+					require.True(t, ok)
+					remaining = time.Until(deadline)
+					return argusiov1alpha1.AttestationResult{}, nil
+				},
+			}
+			prov := &MockProvider{NewFn: WithNewFn(mock, nil)}
+			schema.ForceRegister(prov, "mock")
+			cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(testCase.provider).Build()
+			c, err := GetAttestationClient(context.Background(), cl, makeComponentAttestation())
+			require.NoError(t, err)
+			_, err = c.Attest(context.Background())
+			require.NoError(t, err)
+			assert.LessOrEqual(t, remaining, testCase.expectedDeadline)
+			assert.Greater(t, remaining, testCase.expectedDeadline-time.Second)
+		})
+	}
+}
+
+func TestGetSchedule(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	from := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.ComponentAttestation
+		provider      *argusiov1alpha1.AttestationProvider
+		expectedNext  time.Time
+		expectedError string
+	}{
+		{
+			name:         "default interval",
+			res:          makeComponentAttestation(),
+			provider:     makeAttestationProvider(),
+			expectedNext: from.Add(time.Minute),
+		},
+		{
+			name:         "provider schedule",
+			res:          makeComponentAttestation(),
+			provider:     makeAttestationProvider(WithProviderSchedule("1h")),
+			expectedNext: from.Add(time.Hour),
+		},
+		{
+			name:         "attestation schedule overrides provider",
+			res:          makeComponentAttestation(WithSchedule("CRON_TZ=UTC 0 2 * * *")),
+			provider:     makeAttestationProvider(WithProviderSchedule("1h")),
+			expectedNext: time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "missing provider",
+			res:           makeComponentAttestation(),
+			provider:      makeAttestationProvider(func(p *argusiov1alpha1.AttestationProvider) { p.Name = "other" }),
+			expectedError: "could not get provider spec 'prov'",
+		},
+		{
+			name:          "invalid schedule",
+			res:           makeComponentAttestation(WithSchedule("every day")),
+			provider:      makeAttestationProvider(),
+			expectedError: "invalid schedule \"every day\"",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(testCase.provider).Build()
+			s, err := GetSchedule(context.Background(), cl, testCase.res, time.Minute)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedNext, s.Next(from).UTC())
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestResultReason(t *testing.T) {
+	assert.Equal(t, argusiov1alpha1.ConditionReasonPassed, ResultReason(argusiov1alpha1.AttestationResultTypePass))
+	assert.Equal(t, argusiov1alpha1.ConditionReasonFailed, ResultReason(argusiov1alpha1.AttestationResultTypeFail))
+	assert.Equal(t, argusiov1alpha1.ConditionReasonNotStarted, ResultReason(argusiov1alpha1.AttestationResultTypeNotStarted))
+	assert.Equal(t, argusiov1alpha1.ConditionReasonUnknown, ResultReason(""))
+}
+
 type ProvFn func(*argusiov1alpha1.AttestationProvider)
 
 func DefaultNewFn() func(*argusiov1alpha1.AttestationProviderSpec) (schema.AttestationClient, error) {
@@ -113,6 +229,16 @@ func WithType(t string) ProvFn {
 		p.Spec.Type = t
 	}
 }
+func WithTimeout(d time.Duration) ProvFn {
+	return func(p *argusiov1alpha1.AttestationProvider) {
+		p.Spec.Timeout = &metav1.Duration{Duration: d}
+	}
+}
+func WithProviderSchedule(s string) ProvFn {
+	return func(p *argusiov1alpha1.AttestationProvider) {
+		p.Spec.Schedule = s
+	}
+}
 func makeAttestationProvider(f ...ProvFn) *argusiov1alpha1.AttestationProvider {
 	res := &argusiov1alpha1.AttestationProvider{
 		ObjectMeta: metav1.ObjectMeta{
@@ -136,6 +262,12 @@ func makeAttestationProvider(f ...ProvFn) *argusiov1alpha1.AttestationProvider {
 
 type MutationFn func(*argusiov1alpha1.ComponentAttestation)
 
+func WithSchedule(s string) MutationFn {
+	return func(res *argusiov1alpha1.ComponentAttestation) {
+		res.Spec.Schedule = s
+	}
+}
+
 func makeComponentAttestation(f ...MutationFn) *argusiov1alpha1.ComponentAttestation {
 	res := &argusiov1alpha1.ComponentAttestation{
 		ObjectMeta: metav1.ObjectMeta{
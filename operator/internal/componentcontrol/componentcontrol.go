@@ -3,23 +3,29 @@ package componentcontrol
 import (
 	"context"
 	"fmt"
+	"time"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/complianceexception"
+	"github.com/ContainerSolutions/argus/operator/internal/componentassessment"
 	"github.com/ContainerSolutions/argus/operator/internal/utils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetValidComponentAssessments(ctx context.Context, cl client.Client, res argusiov1alpha1.ComponentControl) ([]argusiov1alpha1.NamespacedName, int, error) {
+// GetValidComponentAssessments returns the ComponentAssessments applicable to a ComponentControl, along with
+// how many of them are attested, and how many of the others are waived.
+func GetValidComponentAssessments(ctx context.Context, cl client.Client, res argusiov1alpha1.ComponentControl, waivers complianceexception.Waivers) ([]argusiov1alpha1.NamespacedName, int, int, error) {
 	total := []argusiov1alpha1.NamespacedName{}
 	valid := 0
+	waived := 0
 	list := argusiov1alpha1.ComponentAssessmentList{}
 	ComponentName, ok := res.Labels["argus.io/Component"]
 	if !ok {
-		return nil, 0, fmt.Errorf("object does not have expected label 'argus.io/Component'")
+		return nil, 0, 0, fmt.Errorf("object does not have expected label 'argus.io/Component'")
 	}
 	err := cl.List(ctx, &list, client.MatchingLabels{"argus.io/Component": ComponentName})
 	if err != nil {
-		return nil, 0, fmt.Errorf("could not list ComponentAssessment: %w", err)
+		return nil, 0, 0, fmt.Errorf("could not list ComponentAssessment: %w", err)
 	}
 	for _, Assessment := range list.Items {
 		if utils.Contains(res.Spec.RequiredAssessmentClasses, Assessment.Spec.Class) {
@@ -30,11 +36,38 @@ func GetValidComponentAssessments(ctx context.Context, cl client.Client, res arg
 					Namespace: Assessment.Namespace,
 				}
 				total = append(total, name)
-				if Assessment.Status.TotalAttestations == Assessment.Status.PassedAttestations && Assessment.Status.TotalAttestations > 0 {
+				if componentassessment.Attested(Assessment) {
 					valid = valid + 1
+				} else if waivers.Waives(Assessment) {
+					waived = waived + 1
 				}
 			}
 		}
 	}
-	return total, valid, nil
+	return total, valid, waived, nil
+}
+
+// GetWaivers returns what the active ComplianceExceptions waive for a ComponentControl.
+func GetWaivers(ctx context.Context, cl client.Client, res argusiov1alpha1.ComponentControl, now time.Time) (complianceexception.Waivers, error) {
+	ComponentName, ok := res.Labels["argus.io/Component"]
+	if !ok {
+		return complianceexception.Waivers{}, fmt.Errorf("object does not have expected label 'argus.io/Component'")
+	}
+	return complianceexception.GetWaivers(ctx, cl, ComponentName, res.Spec.Definition.Code, res.Spec.Definition.Version, now)
+}
+
+// Implemented reports whether every applicable ComponentAssessment of a ComponentControl is attested.
+// A ComponentControl without any ComponentAssessment is not implemented.
+func Implemented(res argusiov1alpha1.ComponentControl) bool {
+	return res.Status.TotalAssessments == res.Status.ValidAssessments && res.Status.TotalAssessments > 0
+}
+
+// Waived reports whether a ComponentControl that is not implemented is waived, either as a whole,
+// or because every applicable ComponentAssessment that is not attested is waived.
+func Waived(res argusiov1alpha1.ComponentControl, waivers complianceexception.Waivers) bool {
+	if Implemented(res) {
+		return false
+	}
+	return waivers.Control ||
+		(res.Status.TotalAssessments > 0 && res.Status.ValidAssessments+res.Status.WaivedAssessments == res.Status.TotalAssessments)
 }
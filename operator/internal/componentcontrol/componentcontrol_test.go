@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/complianceexception"
 	"github.com/ContainerSolutions/argus/operator/internal/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,12 +21,14 @@ func TestGetValidComponentAssessments(t *testing.T) {
 	err := argusiov1alpha1.AddToScheme(commonScheme)
 	require.Nil(t, err)
 	testCases := []struct {
-		name          string
-		res           *argusiov1alpha1.ComponentControl
-		expectedList  []argusiov1alpha1.NamespacedName
-		expectedValid int
-		expectedError string
-		cl            client.Client
+		name           string
+		res            *argusiov1alpha1.ComponentControl
+		waivers        complianceexception.Waivers
+		expectedList   []argusiov1alpha1.NamespacedName
+		expectedValid  int
+		expectedWaived int
+		expectedError  string
+		cl             client.Client
 	}{
 		{
 			name:          "No Assessments",
@@ -69,6 +72,48 @@ func TestGetValidComponentAssessments(t *testing.T) {
 			expectedError: "",
 			cl:            fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeNewComponentAssessment(WithPass(0))).Build(),
 		},
+		{
+			name:    "Assessments waived with the Control",
+			res:     makeComponentControl(),
+			waivers: complianceexception.Waivers{Control: true},
+			expectedList: []argusiov1alpha1.NamespacedName{
+				{
+					Name:      "Assessment",
+					Namespace: "test",
+				},
+			},
+			expectedValid:  0,
+			expectedWaived: 1,
+			cl:             fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeNewComponentAssessment(WithPass(0))).Build(),
+		},
+		{
+			name:    "Assessments of a waived Assessment",
+			res:     makeComponentControl(),
+			waivers: complianceexception.Waivers{Assessments: map[string]bool{"parent": true}},
+			expectedList: []argusiov1alpha1.NamespacedName{
+				{
+					Name:      "Assessment",
+					Namespace: "test",
+				},
+			},
+			expectedValid:  0,
+			expectedWaived: 1,
+			cl:             fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeNewComponentAssessment(WithPass(0), WithAssessment("parent"))).Build(),
+		},
+		{
+			name:    "attested Assessments are not waived",
+			res:     makeComponentControl(),
+			waivers: complianceexception.Waivers{Control: true},
+			expectedList: []argusiov1alpha1.NamespacedName{
+				{
+					Name:      "Assessment",
+					Namespace: "test",
+				},
+			},
+			expectedValid:  1,
+			expectedWaived: 0,
+			cl:             fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeNewComponentAssessment()).Build(),
+		},
 		{
 			name:          "Error listing",
 			res:           makeComponentControl(),
@@ -82,11 +127,12 @@ func TestGetValidComponentAssessments(t *testing.T) {
 		testCase := testCases[i]
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
-			list, valid, err := GetValidComponentAssessments(context.Background(), testCase.cl, *testCase.res)
+			list, valid, waived, err := GetValidComponentAssessments(context.Background(), testCase.cl, *testCase.res, testCase.waivers)
 			if testCase.expectedError == "" {
 				require.NoError(t, err)
 				assert.Equal(t, testCase.expectedList, list)
 				assert.Equal(t, testCase.expectedValid, valid)
+				assert.Equal(t, testCase.expectedWaived, waived)
 			} else {
 				assert.ErrorContains(t, err, testCase.expectedError)
 			}
@@ -94,6 +140,37 @@ func TestGetValidComponentAssessments(t *testing.T) {
 	}
 }
 
+func TestWaived(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		total    int
+		valid    int
+		waived   int
+		waivers  complianceexception.Waivers
+		expected bool
+	}{
+		{name: "implemented", total: 2, valid: 2, waivers: complianceexception.Waivers{Control: true}, expected: false},
+		{name: "Control waived", total: 2, valid: 1, waived: 1, waivers: complianceexception.Waivers{Control: true}, expected: true},
+		{name: "Control waived without Assessments", total: 0, waivers: complianceexception.Waivers{Control: true}, expected: true},
+		{name: "every missing Assessment waived", total: 3, valid: 1, waived: 2, expected: true},
+		{name: "some missing Assessments waived", total: 3, valid: 1, waived: 1, expected: false},
+		{name: "nothing waived", total: 2, valid: 1, expected: false},
+		{name: "no Assessments", total: 0, expected: false},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			res := makeComponentControl()
+			res.Status.TotalAssessments = testCase.total
+			res.Status.ValidAssessments = testCase.valid
+			res.Status.WaivedAssessments = testCase.waived
+			assert.Equal(t, testCase.expected, Waived(*res, testCase.waivers))
+		})
+	}
+}
+
 type mutateFn func(*argusiov1alpha1.ComponentControl)
 
 func WithLabels(labels map[string]string) mutateFn {
@@ -137,6 +214,13 @@ func WithPass(pass int) AssessmentFn {
 		res.Status.PassedAttestations = pass
 	}
 }
+
+func WithAssessment(name string) AssessmentFn {
+	return func(res *argusiov1alpha1.ComponentAssessment) {
+		res.Labels["argus.io/Assessment"] = name
+	}
+}
+
 func makeNewComponentAssessment(f ...AssessmentFn) *argusiov1alpha1.ComponentAssessment {
 	res := &argusiov1alpha1.ComponentAssessment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,88 @@
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Set sets a condition at the generation of obj. When the condition flips from a previous status,
+// an event is recorded on obj with the condition reason and message. The event is a warning when
+// the object went into a bad state. recorder may be nil.
+func Set(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, conditionType string, status bool, reason, message string) {
+	s := metav1.ConditionFalse
+	if status {
+		s = metav1.ConditionTrue
+	}
+	previous := meta.FindStatusCondition(*conditions, conditionType)
+	flipped := previous != nil && previous.Status != s
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             s,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	if !flipped || recorder == nil {
+		return
+	}
+	eventType := "Normal"
+	if status == (conditionType == argusiov1alpha1.ConditionTypeProviderError) {
+		eventType = "Warning"
+	}
+	recorder.Event(obj, eventType, reason, fmt.Sprintf("%v is now %v: %v", conditionType, s, message))
+}
+
+// SetReady sets the Ready condition according to the outcome of a reconciliation.
+func SetReady(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, err error) {
+	if err != nil {
+		Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeReady, false, argusiov1alpha1.ConditionReasonReconcileError, err.Error())
+		return
+	}
+	Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeReady, true, argusiov1alpha1.ConditionReasonReconciled, "")
+}
+
+// SetCompliant sets the Compliant condition.
+func SetCompliant(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, compliant bool, message string) {
+	if compliant {
+		Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeCompliant, true, argusiov1alpha1.ConditionReasonImplemented, message)
+		return
+	}
+	Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeCompliant, false, argusiov1alpha1.ConditionReasonNotImplemented, message)
+}
+
+// SetWaived sets the Compliant condition of an object that is not implemented, but waived by ComplianceExceptions.
+func SetWaived(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, message string) {
+	Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeCompliant, true, argusiov1alpha1.ConditionReasonWaived, message)
+}
+
+// SetAttested sets the Attested condition from the number of passed attestations.
+// Nothing is attested until at least one attestation passed.
+func SetAttested(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, passed, total int) {
+	message := fmt.Sprintf("%v/%v attestations passed", passed, total)
+	switch {
+	case total == 0:
+		Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeAttested, false, argusiov1alpha1.ConditionReasonNotStarted, message)
+	case passed == total:
+		Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeAttested, true, argusiov1alpha1.ConditionReasonPassed, message)
+	default:
+		Set(recorder, obj, conditions, argusiov1alpha1.ConditionTypeAttested, false, argusiov1alpha1.ConditionReasonFailed, message)
+	}
+}
+
+// NotReady sets the Ready condition of obj to False with err, and patches the status of obj.
+// It returns err, for the reconciler to return it and be retried.
+func NotReady(ctx context.Context, cl client.Client, recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, err error) error {
+	original := obj.DeepCopyObject().(client.Object)
+	SetReady(recorder, obj, conditions, err)
+	patchErr := cl.Status().Patch(ctx, obj, client.MergeFrom(original))
+	if patchErr != nil {
+		return fmt.Errorf("%w (could not update Ready condition: %v)", err, patchErr)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSet(t *testing.T) {
+	testCases := []struct {
+		name           string
+		previous       []metav1.Condition
+		conditionType  string
+		status         bool
+		reason         string
+		expectedStatus metav1.ConditionStatus
+		expectedEvents []string
+	}{
+		{
+			name:           "first time does not record an event",
+			conditionType:  argusiov1alpha1.ConditionTypeCompliant,
+			status:         true,
+			reason:         argusiov1alpha1.ConditionReasonImplemented,
+			expectedStatus: metav1.ConditionTrue,
+			expectedEvents: []string{},
+		},
+		{
+			name: "unchanged status does not record an event",
+			previous: []metav1.Condition{
+				{Type: argusiov1alpha1.ConditionTypeCompliant, Status: metav1.ConditionTrue, Reason: argusiov1alpha1.ConditionReasonImplemented},
+			},
+			conditionType:  argusiov1alpha1.ConditionTypeCompliant,
+			status:         true,
+			reason:         argusiov1alpha1.ConditionReasonImplemented,
+			expectedStatus: metav1.ConditionTrue,
+			expectedEvents: []string{},
+		},
+		{
+			name: "compliance lost",
+			previous: []metav1.Condition{
+				{Type: argusiov1alpha1.ConditionTypeCompliant, Status: metav1.ConditionTrue, Reason: argusiov1alpha1.ConditionReasonImplemented},
+			},
+			conditionType:  argusiov1alpha1.ConditionTypeCompliant,
+			status:         false,
+			reason:         argusiov1alpha1.ConditionReasonNotImplemented,
+			expectedStatus: metav1.ConditionFalse,
+			expectedEvents: []string{"Warning NotImplemented Compliant is now False: message"},
+		},
+		{
+			name: "compliance regained",
+			previous: []metav1.Condition{
+				{Type: argusiov1alpha1.ConditionTypeCompliant, Status: metav1.ConditionFalse, Reason: argusiov1alpha1.ConditionReasonNotImplemented},
+			},
+			conditionType:  argusiov1alpha1.ConditionTypeCompliant,
+			status:         true,
+			reason:         argusiov1alpha1.ConditionReasonImplemented,
+			expectedStatus: metav1.ConditionTrue,
+			expectedEvents: []string{"Normal Implemented Compliant is now True: message"},
+		},
+		{
+			name: "provider error is a warning",
+			previous: []metav1.Condition{
+				{Type: argusiov1alpha1.ConditionTypeProviderError, Status: metav1.ConditionFalse, Reason: argusiov1alpha1.ConditionReasonProviderOK},
+			},
+			conditionType:  argusiov1alpha1.ConditionTypeProviderError,
+			status:         true,
+			reason:         argusiov1alpha1.ConditionReasonProviderFailed,
+			expectedStatus: metav1.ConditionTrue,
+			expectedEvents: []string{"Warning ProviderFailed ProviderError is now True: message"},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := record.NewFakeRecorder(10)
+			res := makeComponent()
+			res.Status.Conditions = testCase.previous
+			Set(recorder, res, &res.Status.Conditions, testCase.conditionType, testCase.status, testCase.reason, "message")
+			condition := meta.FindStatusCondition(res.Status.Conditions, testCase.conditionType)
+			require.NotNil(t, condition)
+			assert.Equal(t, testCase.expectedStatus, condition.Status)
+			assert.Equal(t, testCase.reason, condition.Reason)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+			close(recorder.Events)
+			events := []string{}
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, testCase.expectedEvents, events)
+		})
+	}
+}
+
+func TestSetAttested(t *testing.T) {
+	testCases := []struct {
+		name           string
+		passed         int
+		total          int
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "nothing to attest",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: argusiov1alpha1.ConditionReasonNotStarted,
+		},
+		{
+			name:           "some failed",
+			passed:         1,
+			total:          2,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: argusiov1alpha1.ConditionReasonFailed,
+		},
+		{
+			name:           "all passed",
+			passed:         2,
+			total:          2,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: argusiov1alpha1.ConditionReasonPassed,
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			res := makeComponent()
+			SetAttested(nil, res, &res.Status.Conditions, testCase.passed, testCase.total)
+			condition := meta.FindStatusCondition(res.Status.Conditions, argusiov1alpha1.ConditionTypeAttested)
+			require.NotNil(t, condition)
+			assert.Equal(t, testCase.expectedStatus, condition.Status)
+			assert.Equal(t, testCase.expectedReason, condition.Reason)
+			assert.Equal(t, fmt.Sprintf("%v/%v attestations passed", testCase.passed, testCase.total), condition.Message)
+		})
+	}
+}
+
+func TestNotReady(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	res := makeComponent()
+	cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(res).WithStatusSubresource(res).Build()
+	err = NotReady(context.Background(), cl, nil, res, &res.Status.Conditions, fmt.Errorf("boom"))
+	assert.EqualError(t, err, "boom")
+	stored := argusiov1alpha1.Component{}
+	err = cl.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "test"}, &stored)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(stored.Status.Conditions, argusiov1alpha1.ConditionTypeReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argusiov1alpha1.ConditionReasonReconcileError, condition.Reason)
+	assert.Equal(t, "boom", condition.Message)
+}
+
+// Helpers
+
+func makeComponent() *argusiov1alpha1.Component {
+	return &argusiov1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Namespace:  "test",
+			Generation: 3,
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Component",
+			APIVersion: "argus.io/v1alpha1",
+		},
+	}
+}
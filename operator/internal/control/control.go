@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/componentcontrol"
 	"github.com/ContainerSolutions/argus/operator/internal/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -106,3 +107,36 @@ func CreateOrUpdateComponentControls(ctx context.Context, cl client.Client, sche
 	}
 	return all, nil
 }
+
+// CountImplemented returns how many of the ComponentControls are implemented.
+func CountImplemented(resReqs map[string]argusiov1alpha1.ComponentControl) int {
+	implemented := 0
+	for _, ComponentControl := range resReqs {
+		if componentcontrol.Implemented(ComponentControl) {
+			implemented = implemented + 1
+		}
+	}
+	return implemented
+}
+
+// CountWaived returns how many of the ComponentControls are waived.
+func CountWaived(resReqs map[string]argusiov1alpha1.ComponentControl) int {
+	waived := 0
+	for _, ComponentControl := range resReqs {
+		if ComponentControl.Status.Waived {
+			waived = waived + 1
+		}
+	}
+	return waived
+}
+
+// Validate checks that a Control defines its code and version, and requires at least one Assessment class.
+func Validate(req *argusiov1alpha1.Control) error {
+	if req.Spec.Definition.Code == "" || req.Spec.Definition.Version == "" {
+		return fmt.Errorf("definition: code and version are required")
+	}
+	if len(req.Spec.RequiredAssessmentClasses) == 0 {
+		return fmt.Errorf("requiredAssessmentClasses must list at least one class")
+	}
+	return nil
+}
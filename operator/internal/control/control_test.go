@@ -312,6 +312,45 @@ func TestCreateOrUpdateComponentControls(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           *argusiov1alpha1.Control
+		expectedError string
+	}{
+		{
+			name: "valid",
+			req:  makeControl(),
+		},
+		{
+			name: "missing version",
+			req: makeControl(func(c *argusiov1alpha1.Control) {
+				c.Spec.Definition.Version = ""
+			}),
+			expectedError: "definition: code and version are required",
+		},
+		{
+			name: "no required Assessment classes",
+			req: makeControl(func(c *argusiov1alpha1.Control) {
+				c.Spec.RequiredAssessmentClasses = []string{}
+			}),
+			expectedError: "requiredAssessmentClasses must list at least one class",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(testCase.req)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
 // Helpers
 
 type mutateFunc func(*argusiov1alpha1.ComponentControl)
@@ -23,25 +23,36 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	lib "github.com/ContainerSolutions/argus/operator/internal/assessment"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
 	"github.com/go-logr/logr"
 )
 
 // AssessmentReconciler reconciles a Assessment object
 type AssessmentReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=argus.io,resources=assessments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=argus.io,resources=assessments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=argus.io,resources=assessments/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *AssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("Assessment", req.NamespacedName)
@@ -58,40 +69,67 @@ func (r *AssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	ComponentList := argusiov1alpha1.ComponentList{}
 	err = r.Client.List(ctx, &ComponentList)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not list Components CR: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not list Components CR: %w", err))
 	}
 	Components := ComponentList.Items
 	currentAssessments, err := lib.GetComponentAssessments(ctx, r.Client, &res)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not get ComponentAssessment for Control '%v': %w", res.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not get ComponentAssessment for Control '%v': %w", res.Name, err))
 	}
 	// TODO - If Control Component Class does not match current Components, no need to create new ComponentAssessments
 	newList, err := lib.BuildComponentAssessmentList(ctx, &res, Components)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not build list : %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not build list : %w", err))
 	}
 	err = lib.LifecycleComponentAssessments(ctx, r.Client, newList, currentAssessments)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not remove uneeded ComponentControls: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not remove uneeded ComponentControls: %w", err))
 	}
 	children, err := lib.CreateOrUpdateComponentAssessments(ctx, r.Client, r.Scheme, &res, Components)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not create ComponentAssessment for Control '%v': %w", res.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not create ComponentAssessment for Control '%v': %w", res.Name, err))
 	}
 	// Update Control Status
 	original := res.DeepCopy()
 	res.Status.Children = children
+	conditions.SetAttested(r.Recorder, &res, &res.Status.Conditions, lib.CountAttested(currentAssessments, newList), len(newList))
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	res.Status.ObservedGeneration = res.Generation
 	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not update Control status: %w", err)
 	}
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AssessmentReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&argusiov1alpha1.Assessment{}).
+		For(&argusiov1alpha1.Assessment{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// ComponentAssessment status changes may change whether the Assessment is attested.
+		Owns(&argusiov1alpha1.ComponentAssessment{}).
+		// Components being created, deleted, relabelled or reparented may change which of them an Assessment targets.
+		Watches(&argusiov1alpha1.Component{},
+			handler.EnqueueRequestsFromMapFunc(r.assessmentsForComponent),
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.GenerationChangedPredicate{})),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func (r *AssessmentReconciler) assessmentsForComponent(ctx context.Context, obj client.Object) []reconcile.Request {
+	Component, ok := obj.(*argusiov1alpha1.Component)
+	if !ok {
+		return nil
+	}
+	Assessments, err := lib.AssessmentsForComponent(ctx, r.Client, Component)
+	if err != nil {
+		r.Log.Error(err, "could not map Component to Assessments", "Component", Component.Name)
+		return nil
+	}
+	return mapper.Requests(Assessments)
+}
+
+func (r *AssessmentReconciler) notReady(ctx context.Context, res *argusiov1alpha1.Assessment, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
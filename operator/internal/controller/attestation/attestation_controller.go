@@ -22,14 +22,19 @@ import (
 	"time"
 
 	lib "github.com/ContainerSolutions/argus/operator/internal/attestation"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -38,13 +43,17 @@ import (
 // AttestationReconciler reconciles a Attestation object
 type AttestationReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
 }
 
 //+kubebuilder:rbac:groups=argus.io,resources=attestations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=argus.io,resources=attestations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=argus.io,resources=attestations/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *AttestationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("Attestation", req.NamespacedName)
@@ -60,35 +69,58 @@ func (r *AttestationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	ComponentList := argusiov1alpha1.ComponentAssessmentList{}
 	err = r.Client.List(ctx, &ComponentList)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not list Components CR: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not list Components CR: %w", err))
 	}
 	Components := ComponentList.Items
 	currentAttestations, err := lib.GetComponentAttestations(ctx, r.Client, &res)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not get ComponentAssessment for Control '%v': %w", res.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not get ComponentAssessment for Control '%v': %w", res.Name, err))
 	}
 	err = lib.LifecycleComponentAttestations(ctx, r.Client, res.Spec.AssessmentRef, Components, currentAttestations)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not remove uneeded ComponentControls: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not remove uneeded ComponentControls: %w", err))
 	}
 	children, err := lib.CreateOrUpdateComponentAttestations(ctx, r.Client, r.Scheme, &res, Components)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not create ComponentAssessment for Control '%v': %w", res.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not create ComponentAssessment for Control '%v': %w", res.Name, err))
 	}
 	// Update Control Status
 	original := res.DeepCopy()
 	res.Status.Children = children
+	conditions.SetAttested(r.Recorder, &res, &res.Status.Conditions, lib.CountPassed(currentAttestations), len(currentAttestations))
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	res.Status.ObservedGeneration = res.Generation
 	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not update Control status: %w", err)
 	}
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AttestationReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&argusiov1alpha1.Attestation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// ComponentAttestation results change whether the Attestation is attested.
+		Owns(&argusiov1alpha1.ComponentAttestation{}).
+		// ComponentAssessments being created or deleted change which Components an Attestation runs against.
+		Watches(&argusiov1alpha1.ComponentAssessment{},
+			handler.EnqueueRequestsFromMapFunc(r.attestationsForComponentAssessment),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func (r *AttestationReconciler) attestationsForComponentAssessment(ctx context.Context, obj client.Object) []reconcile.Request {
+	Attestations, err := lib.AttestationsForAssessment(ctx, r.Client, obj.GetLabels()["argus.io/Assessment"])
+	if err != nil {
+		r.Log.Error(err, "could not map ComponentAssessment to Attestations", "ComponentAssessment", obj.GetName())
+		return nil
+	}
+	return mapper.Requests(Attestations)
+}
+
+func (r *AttestationReconciler) notReady(ctx context.Context, res *argusiov1alpha1.Attestation, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
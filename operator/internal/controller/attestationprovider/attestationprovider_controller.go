@@ -0,0 +1,134 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attestationprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/attestationprovider"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+)
+
+// AttestationProviderReconciler reconciles a AttestationProvider object
+type AttestationProviderReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=argus.io,resources=attestationproviders,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=argus.io,resources=attestationproviders/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=argus.io,resources=attestationproviders/finalizers,verbs=update
+//+kubebuilder:rbac:groups=argus.io,resources=componentattestations,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AttestationProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("AttestationProvider", req.NamespacedName)
+	res := argusiov1alpha1.AttestationProvider{}
+	err := r.Client.Get(ctx, req.NamespacedName, &res)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, "could not get AttestationProvider")
+		return ctrl.Result{}, nil
+	}
+	consumers, err := lib.GetConsumers(ctx, r.Client, &res)
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not get consumers of AttestationProvider '%v': %w", res.Name, err))
+	}
+	failing, lastError, lastErrorTime := lib.Health(consumers)
+	// An invalid configuration only changes with the spec, so it is reported without being retried.
+	invalid := lib.Validate(&res)
+	// Update AttestationProvider Status
+	original := res.DeepCopy()
+	res.Status.Attestations = len(consumers)
+	res.Status.FailingAttestations = failing
+	if invalid != nil && invalid.Error() != res.Status.LastError {
+		res.Status.LastError = invalid.Error()
+		res.Status.LastErrorTime = &metav1.Time{Time: time.Now()}
+	} else if lastErrorTime != nil && (res.Status.LastErrorTime == nil || res.Status.LastErrorTime.Before(lastErrorTime)) {
+		res.Status.LastError = lastError
+		res.Status.LastErrorTime = lastErrorTime
+	}
+	conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeProviderError, failing > 0,
+		providerErrorReason(failing), fmt.Sprintf("%v/%v attestations failed with a provider error", failing, len(consumers)))
+	if invalid != nil {
+		conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeReady, false, argusiov1alpha1.ConditionReasonInvalidConfig, invalid.Error())
+	} else {
+		conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	}
+	res.Status.ObservedGeneration = res.Generation
+	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not update AttestationProvider status: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AttestationProviderReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&argusiov1alpha1.AttestationProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// ComponentAttestation runs change the health of their provider.
+		Watches(&argusiov1alpha1.ComponentAttestation{},
+			handler.EnqueueRequestsFromMapFunc(providerOf),
+		).
+		WithOptions(opts).
+		Complete(r)
+}
+
+func providerOf(_ context.Context, obj client.Object) []reconcile.Request {
+	res, ok := obj.(*argusiov1alpha1.ComponentAttestation)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      res.Spec.ProviderRef.Name,
+		Namespace: res.Spec.ProviderRef.Namespace,
+	}}}
+}
+
+func providerErrorReason(failing int) string {
+	if failing > 0 {
+		return argusiov1alpha1.ConditionReasonProviderFailed
+	}
+	return argusiov1alpha1.ConditionReasonProviderOK
+}
+
+func (r *AttestationProviderReconciler) notReady(ctx context.Context, res *argusiov1alpha1.AttestationProvider, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package complianceexception
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/complianceexception"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+)
+
+// ComplianceExceptionReconciler reconciles a ComplianceException object
+type ComplianceExceptionReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=argus.io,resources=complianceexceptions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=argus.io,resources=complianceexceptions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=argus.io,resources=complianceexceptions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=argus.io,resources=components,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *ComplianceExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ComplianceException", req.NamespacedName)
+	res := argusiov1alpha1.ComplianceException{}
+	err := r.Client.Get(ctx, req.NamespacedName, &res)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, "could not get ComplianceException")
+		return ctrl.Result{}, nil
+	}
+	ComponentList := argusiov1alpha1.ComponentList{}
+	err = r.Client.List(ctx, &ComponentList, client.InNamespace(res.Namespace))
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not list Components: %w", err))
+	}
+	targets, err := lib.TargetComponents(&res, ComponentList.Items)
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, err)
+	}
+	// Update ComplianceException Status
+	original := res.DeepCopy()
+	res.Status.Components = targets
+	res.Status.Active = lib.Active(&res, time.Now())
+	control := fmt.Sprintf("%v version %v", res.Spec.ControlRef.Code, res.Spec.ControlRef.Version)
+	if res.Status.Active {
+		conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeActive, true, argusiov1alpha1.ConditionReasonWaived,
+			fmt.Sprintf("Control %v is waived on %v components until %v", control, len(targets), res.Spec.ExpiresAt.Format(time.RFC3339)))
+	} else {
+		conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeActive, false, argusiov1alpha1.ConditionReasonExpired,
+			fmt.Sprintf("Control %v is no longer waived since %v", control, res.Spec.ExpiresAt.Format(time.RFC3339)))
+	}
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	res.Status.ObservedGeneration = res.Generation
+	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not update ComplianceException status: %w", err)
+	}
+	// Reconcile again at expiry, so that the status change lets the waived ComponentControls know.
+	requeue := r.ResyncInterval
+	if res.Status.Active {
+		until := time.Until(res.Spec.ExpiresAt.Time)
+		if requeue == 0 || until < requeue {
+			requeue = until
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ComplianceExceptionReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&argusiov1alpha1.ComplianceException{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Components being created, deleted or relabelled change which of them an exception targets.
+		Watches(&argusiov1alpha1.Component{},
+			handler.EnqueueRequestsFromMapFunc(mapper.All(r.Client, &argusiov1alpha1.ComplianceExceptionList{})),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})),
+		).
+		WithOptions(opts).
+		Complete(r)
+}
+
+func (r *ComplianceExceptionReconciler) notReady(ctx context.Context, res *argusiov1alpha1.ComplianceException, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
@@ -18,14 +18,22 @@ package component
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	res "github.com/ContainerSolutions/argus/operator/internal/component"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -34,13 +42,17 @@ import (
 // ComponentReconciler reconciles a Component object
 type Reconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
 }
 
 //+kubebuilder:rbac:groups=argus.io,resources=components,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=argus.io,resources=components/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=argus.io,resources=components/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("Component", req.NamespacedName)
@@ -60,28 +72,51 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	err = r.Client.List(ctx, &ComponentControlList, client.MatchingLabels{"argus.io/Component": Component.Name})
 	if err != nil {
 		log.Error(err, "could not list ComponentControls to update compliance status for %v", Component.Name)
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.notReady(ctx, &Component, err)
 	}
 	res.UpdateControls(ComponentControlList, &Component)
-	err = r.Client.Status().Patch(ctx, &Component, client.MergeFrom(originalRes))
+	// Parents are updated first, so that they see this child when the status update below enqueues them.
+	err = res.UpdateChild(ctx, r.Client, &Component)
 	if err != nil {
 		// Should we error here?
-		log.Error(err, "could not update Component Controls")
+		log.Error(err, "could not update parent child definition")
 	}
-	err = res.UpdateChild(ctx, r.Client, &Component)
+	conditions.SetCompliant(r.Recorder, &Component, &Component.Status.Conditions, res.Compliant(&Component),
+		fmt.Sprintf("%v/%v controls implemented, %v waived", Component.Status.ImplementedControls, Component.Status.TotalControls, Component.Status.WaivedControls))
+	conditions.SetReady(r.Recorder, &Component, &Component.Status.Conditions, err)
+	Component.Status.ObservedGeneration = Component.Generation
+	// Status changes enqueue the parents of the Component, so a patch bumping RunAt alone would keep
+	// Components whose parents lead back to them reconciling forever.
+	if !res.StatusChanged(originalRes, &Component) {
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+	err = r.Client.Status().Patch(ctx, &Component, client.MergeFrom(originalRes))
 	if err != nil {
 		// Should we error here?
-		log.Error(err, "could not update parent child definition")
+		log.Error(err, "could not update Component Controls")
 	}
-	// Check Parents
-	// TODO Make this a configuration
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&argusiov1alpha1.Component{}).
+		For(&argusiov1alpha1.Component{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&argusiov1alpha1.ComponentControl{}, handler.EnqueueRequestsFromMapFunc(mapper.ByLabelName("argus.io/Component"))).
+		// Any change to a Component, including its status, may change the compliance of its parents.
+		Watches(&argusiov1alpha1.Component{}, handler.EnqueueRequestsFromMapFunc(parentsOf)).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func parentsOf(ctx context.Context, obj client.Object) []reconcile.Request {
+	Component, ok := obj.(*argusiov1alpha1.Component)
+	if !ok {
+		return nil
+	}
+	return mapper.Requests(res.Parents(Component))
+}
+
+func (r *Reconciler) notReady(ctx context.Context, res *argusiov1alpha1.Component, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
@@ -22,14 +22,20 @@ import (
 	"time"
 
 	lib "github.com/ContainerSolutions/argus/operator/internal/componentassessment"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
 	"github.com/ContainerSolutions/argus/operator/internal/metrics"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -38,13 +44,17 @@ import (
 // ComponentAssessmentReconciler reconciles a ComponentAssessment object
 type ComponentAssessmentReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
 }
 
 //+kubebuilder:rbac:groups=argus.io,resources=componentassessments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=argus.io,resources=componentassessments/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=argus.io,resources=componentassessments/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ComponentAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ComponentAssessment", req.NamespacedName)
@@ -60,7 +70,7 @@ func (r *ComponentAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.
 	//log.Info("Reconciling ComponentAssessment", "ComponentAssessment", res.Name)
 	attestations, err := lib.ListComponentAttestations(ctx, r.Client, &res)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not list ComponentAttestations: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not list ComponentAttestations: %w", err))
 	}
 	children, valid := lib.GetValidComponentAttestations(ctx, attestations)
 	original := res.DeepCopy()
@@ -68,6 +78,9 @@ func (r *ComponentAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.
 	res.Status.TotalAttestations = len(children)
 	res.Status.PassedAttestations = valid
 	res.Status.RunAt = metav1.Now()
+	conditions.SetAttested(r.Recorder, &res, &res.Status.Conditions, valid, len(children))
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	res.Status.ObservedGeneration = res.Generation
 	labels := map[string]string{
 		"Component":  res.Labels["argus.io/Component"],
 		"Assessment": res.Labels["argus.io/Assessment"],
@@ -79,29 +92,20 @@ func (r *ComponentAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not update Control status: %w", err)
 	}
-	// // Update ComponentControls metadata (force reconciliation)
-	// list := argusiov1alpha1.ComponentControlList{}
-	// err = r.Client.List(ctx, &list, client.MatchingLabels{"argus.io/Control": res.Labels["argus.io/Control"], "argus.io/Component": res.Labels["argus.io/Component"]})
-	// if err != nil {
-	// 	return ctrl.Result{}, fmt.Errorf("could not get ComponentControl: %w", err)
-	// }
-	// for _, resReq := range list.Items {
-	// 	originalResReq := resReq.DeepCopy()
-	// 	resReq.ObjectMeta.Annotations = map[string]string{fmt.Sprintf("%v.Assessment.argus.io/lastRun", res.Name): time.Now().String()}
-	// 	err = r.Client.Patch(ctx, &resReq, client.MergeFrom(originalResReq))
-	// 	if err != nil {
-	// 		return ctrl.Result{}, fmt.Errorf("could not trigger ComponentControl reconciliation: %w", err)
-	// 	}
-
-	// }
-
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ComponentAssessmentReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&argusiov1alpha1.ComponentAssessment{}).
+		For(&argusiov1alpha1.ComponentAssessment{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&argusiov1alpha1.ComponentAttestation{},
+			handler.EnqueueRequestsFromMapFunc(mapper.ByLabels(r.Client, &argusiov1alpha1.ComponentAssessmentList{}, "argus.io/Assessment", "argus.io/Component")),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func (r *ComponentAssessmentReconciler) notReady(ctx context.Context, res *argusiov1alpha1.ComponentAssessment, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
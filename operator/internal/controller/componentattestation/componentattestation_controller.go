@@ -22,12 +22,18 @@ import (
 	"time"
 
 	lib "github.com/ContainerSolutions/argus/operator/internal/componentattestation"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
+	"github.com/ContainerSolutions/argus/operator/internal/schedule"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
@@ -37,14 +43,18 @@ import (
 // ComponentAttestationReconciler reconciles a ComponentAttestation object
 type ComponentAttestationReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Interval is how often attestations run when neither they nor their provider set a schedule.
+	Interval time.Duration
 }
 
 //+kubebuilder:rbac:groups=argus.io,resources=componentattestations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=argus.io,resources=componentattestations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=argus.io,resources=componentattestations/finalizers,verbs=update
 //+kubebuilder:rbac:groups=argus.io,resources=attestationproviders,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ComponentAttestationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var err error
@@ -59,10 +69,33 @@ func (r *ComponentAttestationReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, nil
 	}
 	//log.Info("Reconciling ComponentAttestation", "ComponentAttestation", res.Name)
+	sched, err := lib.GetSchedule(ctx, r.Client, &res, r.Interval)
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not get schedule: %w", err))
+	}
+	// Timestamps are stored with second precision, so slots are computed the same way.
+	now := time.Now().Truncate(time.Second)
+	lastRun := time.Time{}
+	if res.Status.LastRunAt != nil {
+		lastRun = res.Status.LastRunAt.Time
+	}
+	if res.Status.ObservedGeneration == res.Generation && !schedule.Due(sched, lastRun, now) {
+		// Not due yet. The schedule may still have changed since the last run.
+		next := sched.Next(lastRun)
+		if res.Status.NextRunAt == nil || !res.Status.NextRunAt.Time.Equal(next) {
+			original := res.DeepCopy()
+			res.Status.NextRunAt = &metav1.Time{Time: next}
+			err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("could not update ComponentAttestation status: %w", err)
+			}
+		}
+		return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+	}
 	// Get Attestation Client
 	attestationClient, err := lib.GetAttestationClient(ctx, r.Client, &res)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.providerFailed(ctx, &res, err)
 	}
 	defer func() {
 		e := attestationClient.Close()
@@ -70,38 +103,58 @@ func (r *ComponentAttestationReconciler) Reconcile(ctx context.Context, req ctrl
 			err = fmt.Errorf("error closing client: %w", e)
 		}
 	}() // Prepare Call according to attestation provider logic
-	result, err := attestationClient.Attest()
+	result, err := attestationClient.Attest(ctx)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.providerFailed(ctx, &res, err)
 	}
 	// Update Status
 	original := res.DeepCopy()
 	res.Status.Result = result
 	res.Status.Status = "True"
+	next := sched.Next(now)
+	res.Status.LastRunAt = &metav1.Time{Time: now}
+	res.Status.NextRunAt = &metav1.Time{Time: next}
+	res.Status.ObservedGeneration = res.Generation
+	conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeAttested,
+		result.Result == argusiov1alpha1.AttestationResultTypePass, lib.ResultReason(result.Result), result.Reason)
+	if result.Err != "" {
+		conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeProviderError, true, argusiov1alpha1.ConditionReasonProviderFailed, result.Err)
+	} else {
+		conditions.Set(r.Recorder, &res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeProviderError, false, argusiov1alpha1.ConditionReasonProviderOK, "")
+	}
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
 	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not update Control status: %w", err)
 	}
-	// // Update ComponentAssessment Metadata (to force reconciliation)
-	// resImp := argusiov1alpha1.ComponentAssessment{}
-	// resImpName := fmt.Sprintf("%v-%v", res.Labels["argus.io/Assessment"], res.Labels["argus.io/Component"])
-	// err = r.Client.Get(ctx, types.NamespacedName{Namespace: res.Namespace, Name: resImpName}, &resImp)
-	// if err != nil {
-	// 	return ctrl.Result{}, fmt.Errorf("could not get ComponentAssessment: %w", err)
-	// }
-	// originalResImp := resImp.DeepCopy()
-	// resImp.ObjectMeta.Annotations = map[string]string{fmt.Sprintf("%v.attestation.argus.io/lastRun", res.Name): time.Now().String()}
-	// err = r.Client.Patch(ctx, &resImp, client.MergeFrom(originalResImp))
-	// if err != nil {
-	// 	return ctrl.Result{}, fmt.Errorf("could not trigger ComponentAssessment reconciliation: %w", err)
-	// }
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: time.Until(next)}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ComponentAttestationReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&argusiov1alpha1.ComponentAttestation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// A provider schedule applies to the ComponentAttestations that do not set their own.
+		Watches(&argusiov1alpha1.AttestationProvider{},
+			handler.EnqueueRequestsFromMapFunc(mapper.ByProviderRef(r.Client)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func (r *ComponentAttestationReconciler) notReady(ctx context.Context, res *argusiov1alpha1.ComponentAttestation, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
+
+// providerFailed records that the attestation provider could not run, and returns err.
+func (r *ComponentAttestationReconciler) providerFailed(ctx context.Context, res *argusiov1alpha1.ComponentAttestation, err error) error {
+	original := res.DeepCopy()
+	conditions.Set(r.Recorder, res, &res.Status.Conditions, argusiov1alpha1.ConditionTypeProviderError, true, argusiov1alpha1.ConditionReasonProviderFailed, err.Error())
+	conditions.SetReady(r.Recorder, res, &res.Status.Conditions, err)
+	patchErr := r.Client.Status().Patch(ctx, res, client.MergeFrom(original))
+	if patchErr != nil {
+		return fmt.Errorf("%w (could not update status: %v)", err, patchErr)
+	}
+	return err
+}
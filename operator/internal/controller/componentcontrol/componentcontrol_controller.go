@@ -23,26 +23,39 @@ import (
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	lib "github.com/ContainerSolutions/argus/operator/internal/componentcontrol"
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
 	"github.com/ContainerSolutions/argus/operator/internal/metrics"
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // ComponentControlReconciler reconciles a ComponentControl object
 type ComponentControlReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
 }
 
 //+kubebuilder:rbac:groups=argus.io,resources=componentcontrols,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=argus.io,resources=componentcontrols/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=argus.io,resources=componentcontrols/finalizers,verbs=update
+//+kubebuilder:rbac:groups=argus.io,resources=complianceexceptions,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ComponentControlReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ComponentControl", req.NamespacedName)
@@ -56,13 +69,20 @@ func (r *ComponentControlReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 	//log.Info("Reconciling ComponentControl", "ComponentControl", res.Name)
-	Assessments, valid, err := lib.GetValidComponentAssessments(ctx, r.Client, res)
+	waivers, err := lib.GetWaivers(ctx, r.Client, res, time.Now())
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not get Component Assessments for Control '%v': %w", res.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not get ComplianceExceptions for Control '%v': %w", res.Name, err))
+	}
+	Assessments, valid, waived, err := lib.GetValidComponentAssessments(ctx, r.Client, res, waivers)
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not get Component Assessments for Control '%v': %w", res.Name, err))
 	}
 	original := res.DeepCopy()
 	res.Status.ValidAssessments = valid
 	res.Status.TotalAssessments = len(Assessments)
+	res.Status.WaivedAssessments = waived
+	res.Status.Exceptions = waivers.Exceptions
+	res.Status.Waived = lib.Waived(res, waivers)
 	res.Status.ApplicableComponentAssessments = Assessments
 	res.Status.Status = "Not Implemented"
 	res.Status.RunAt = metav1.Now()
@@ -72,33 +92,67 @@ func (r *ComponentControlReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 	metrics.GetGaugeVec(metrics.AssessmentTotalKey).With(labels).Set(float64(res.Status.TotalAssessments))
 	metrics.GetGaugeVec(metrics.AssessmentValidKey).With(labels).Set(float64(res.Status.ValidAssessments))
+	metrics.GetGaugeVec(metrics.AssessmentWaivedKey).With(labels).Set(float64(res.Status.WaivedAssessments))
 
-	if res.Status.TotalAssessments == res.Status.ValidAssessments && res.Status.TotalAssessments > 0 {
+	message := fmt.Sprintf("%v/%v assessments attested", res.Status.ValidAssessments, res.Status.TotalAssessments)
+	switch {
+	case lib.Implemented(res):
 		res.Status.Status = "Implemented"
+		conditions.SetCompliant(r.Recorder, &res, &res.Status.Conditions, true, message)
+	case res.Status.Waived:
+		res.Status.Status = "Waived"
+		conditions.SetWaived(r.Recorder, &res, &res.Status.Conditions, fmt.Sprintf("%v, %v waived", message, res.Status.WaivedAssessments))
+	default:
+		conditions.SetCompliant(r.Recorder, &res, &res.Status.Conditions, false, message)
 	}
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	res.Status.ObservedGeneration = res.Generation
 	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not update ComponentControl status: %w", err)
 	}
-	// // Update Component metadata (force reconciliation)
-	// list := argusiov1alpha1.Component{}
-	// err = r.Client.Get(ctx, types.NamespacedName{Name: res.Labels["argus.io/Component"], Namespace: res.Namespace}, &list)
-	// if err != nil {
-	// 	return ctrl.Result{}, fmt.Errorf("could not get Component: %w", err)
-	// }
-	// originalResReq := list.DeepCopy()
-	// list.ObjectMeta.Annotations = map[string]string{fmt.Sprintf("%v.Control.argus.io/lastRun", res.Name): time.Now().String()}
-	// err = r.Client.Patch(ctx, &list, client.MergeFrom(originalResReq))
-	// if err != nil {
-	// 	return ctrl.Result{}, fmt.Errorf("could not trigger Component reconciliation: %w", err)
-	// }
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	// Waivers lapse without any event, so reconcile again when the first of them expires.
+	requeue := r.ResyncInterval
+	if waivers.Expiry != nil {
+		until := time.Until(*waivers.Expiry)
+		if requeue == 0 || until < requeue {
+			requeue = until
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ComponentControlReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&argusiov1alpha1.ComponentControl{}).
+		For(&argusiov1alpha1.ComponentControl{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&argusiov1alpha1.ComponentAssessment{},
+			handler.EnqueueRequestsFromMapFunc(mapper.ByLabels(r.Client, &argusiov1alpha1.ComponentControlList{}, "argus.io/Component", "argus.io/Control")),
+		).
+		// ComplianceExceptions waive the Control of every ComponentControl they may target.
+		Watches(&argusiov1alpha1.ComplianceException{}, handler.EnqueueRequestsFromMapFunc(r.waivedBy)).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func (r *ComponentControlReconciler) waivedBy(ctx context.Context, obj client.Object) []reconcile.Request {
+	res, ok := obj.(*argusiov1alpha1.ComplianceException)
+	if !ok {
+		return nil
+	}
+	list := argusiov1alpha1.ComponentControlList{}
+	err := r.Client.List(ctx, &list, client.MatchingLabels{"argus.io/Control": fmt.Sprintf("%v_%v", res.Spec.ControlRef.Code, res.Spec.ControlRef.Version)})
+	if err != nil {
+		r.Log.Error(err, "could not list ComponentControls to map", "ComplianceException", res.Name)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+	}
+	return requests
+}
+
+func (r *ComponentControlReconciler) notReady(ctx context.Context, res *argusiov1alpha1.ComponentControl, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
@@ -24,12 +24,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
 	reqlib "github.com/ContainerSolutions/argus/operator/internal/control"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -38,13 +44,17 @@ import (
 // ControlReconciler reconciles a Control object
 type ControlReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=argus.io,resources=controls,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=argus.io,resources=controls/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=argus.io,resources=controls/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ControlReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("Control", req.NamespacedName)
@@ -61,20 +71,20 @@ func (r *ControlReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	ComponentList := argusiov1alpha1.ComponentList{}
 	err = r.Client.List(ctx, &ComponentList)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not list Components CR: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &Control, fmt.Errorf("could not list Components CR: %w", err))
 	}
 	Components := ComponentList.Items
 	currentResReqs, err := reqlib.GetComponentControlsFromControl(ctx, r.Client, &Control)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not get Componentsrequiements for Control '%v': %w", Control.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &Control, fmt.Errorf("could not get Componentsrequiements for Control '%v': %w", Control.Name, err))
 	}
 	err = reqlib.LifecycleComponentControls(ctx, r.Client, Control.Spec.ApplicableComponentClasses, Components, currentResReqs)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not remove uneeded ComponentControls: %w", err)
+		return ctrl.Result{}, r.notReady(ctx, &Control, fmt.Errorf("could not remove uneeded ComponentControls: %w", err))
 	}
 	children, err := reqlib.CreateOrUpdateComponentControls(ctx, r.Client, r.Scheme, &Control, Components)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("could not create ComponentControl for Control '%v': %w", Control.Name, err)
+		return ctrl.Result{}, r.notReady(ctx, &Control, fmt.Errorf("could not create ComponentControl for Control '%v': %w", Control.Name, err))
 	}
 	// Update Control Status
 	original := Control.DeepCopy()
@@ -85,17 +95,34 @@ func (r *ControlReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 	hexSHA := sha512.Sum512(ControlSpecbytes)
 	Control.Status.ControlHash = hex.EncodeToString(hexSHA[:])
+	implemented := reqlib.CountImplemented(currentResReqs)
+	waived := reqlib.CountWaived(currentResReqs)
+	conditions.SetCompliant(r.Recorder, &Control, &Control.Status.Conditions, implemented+waived == len(currentResReqs),
+		fmt.Sprintf("%v/%v components implement the control, %v waived", implemented, len(currentResReqs), waived))
+	conditions.SetReady(r.Recorder, &Control, &Control.Status.Conditions, nil)
+	Control.Status.ObservedGeneration = Control.Generation
 	err = r.Client.Status().Patch(ctx, &Control, client.MergeFrom(original))
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not update Control status: %w", err)
 	}
-	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ControlReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&argusiov1alpha1.Control{}).
+		For(&argusiov1alpha1.Control{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// ComponentControl status changes may change the compliance of the Control.
+		Owns(&argusiov1alpha1.ComponentControl{}).
+		// Components being created, deleted or changing classes change which of them a Control applies to.
+		Watches(&argusiov1alpha1.Component{},
+			handler.EnqueueRequestsFromMapFunc(mapper.All(r.Client, &argusiov1alpha1.ControlList{})),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
+
+func (r *ControlReconciler) notReady(ctx context.Context, res *argusiov1alpha1.Control, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
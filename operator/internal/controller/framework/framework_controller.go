@@ -0,0 +1,160 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ContainerSolutions/argus/operator/internal/conditions"
+	lib "github.com/ContainerSolutions/argus/operator/internal/framework"
+	"github.com/ContainerSolutions/argus/operator/internal/mapper"
+	"github.com/ContainerSolutions/argus/operator/internal/metrics"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+)
+
+// FrameworkReconciler reconciles a Framework object
+type FrameworkReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ResyncInterval is how often objects are reconciled regardless of watch events. Zero disables it.
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=argus.io,resources=frameworks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=argus.io,resources=frameworks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=argus.io,resources=frameworks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=argus.io,resources=controls,verbs=get;list;watch
+//+kubebuilder:rbac:groups=argus.io,resources=componentcontrols,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *FrameworkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("Framework", req.NamespacedName)
+	res := argusiov1alpha1.Framework{}
+	err := r.Client.Get(ctx, req.NamespacedName, &res)
+	if apierrors.IsNotFound(err) {
+		forgetMetrics(req.Name)
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, "could not get Framework")
+		return ctrl.Result{}, nil
+	}
+	ControlList := argusiov1alpha1.ControlList{}
+	err = r.Client.List(ctx, &ControlList)
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not list Controls: %w", err))
+	}
+	controls, err := lib.SelectControls(&res, ControlList.Items)
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, err)
+	}
+	ComponentControlList := argusiov1alpha1.ComponentControlList{}
+	err = r.Client.List(ctx, &ComponentControlList, client.InNamespace(res.Namespace))
+	if err != nil {
+		return ctrl.Result{}, r.notReady(ctx, &res, fmt.Errorf("could not list ComponentControls: %w", err))
+	}
+	components := lib.Compliance(&res, controls, ComponentControlList.Items)
+	// Update Framework Status
+	original := res.DeepCopy()
+	res.Status.Controls = controls
+	res.Status.Components = components
+	res.Status.TotalComponents = len(components)
+	res.Status.CompliantComponents = lib.CountCompliant(components)
+	res.Status.RunAt = metav1.Now()
+	r.recordMetrics(&res)
+	conditions.SetCompliant(r.Recorder, &res, &res.Status.Conditions, res.Status.CompliantComponents == res.Status.TotalComponents,
+		fmt.Sprintf("%v/%v components comply with %v controls", res.Status.CompliantComponents, res.Status.TotalComponents, len(controls)))
+	conditions.SetReady(r.Recorder, &res, &res.Status.Conditions, nil)
+	res.Status.ObservedGeneration = res.Generation
+	err = r.Client.Status().Patch(ctx, &res, client.MergeFrom(original))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not update Framework status: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+}
+
+// frameworkKeys are the metrics set by Frameworks.
+var frameworkKeys = []string{
+	metrics.FrameworkComponentsTotalKey,
+	metrics.FrameworkComponentsValidKey,
+	metrics.FrameworkControlsTotalKey,
+	metrics.FrameworkControlsValidKey,
+}
+
+// recordMetrics exposes the compliance of a Framework. Its series are set from scratch,
+// so that previous versions and Components it no longer applies to disappear.
+func (r *FrameworkReconciler) recordMetrics(res *argusiov1alpha1.Framework) {
+	forgetMetrics(res.Name)
+	labels := map[string]string{
+		"Framework":        res.Name,
+		"FrameworkVersion": res.Spec.Version,
+	}
+	metrics.GetGaugeVec(metrics.FrameworkComponentsTotalKey).With(labels).Set(float64(res.Status.TotalComponents))
+	metrics.GetGaugeVec(metrics.FrameworkComponentsValidKey).With(labels).Set(float64(res.Status.CompliantComponents))
+	for name, compliance := range res.Status.Components {
+		componentLabels := map[string]string{
+			"Framework":        res.Name,
+			"FrameworkVersion": res.Spec.Version,
+			"Component":        name,
+		}
+		metrics.GetGaugeVec(metrics.FrameworkControlsTotalKey).With(componentLabels).Set(float64(compliance.TotalControls))
+		metrics.GetGaugeVec(metrics.FrameworkControlsValidKey).With(componentLabels).Set(float64(compliance.ImplementedControls + compliance.WaivedControls))
+	}
+}
+
+// forgetMetrics deletes the series of a Framework.
+func forgetMetrics(name string) {
+	for _, key := range frameworkKeys {
+		metrics.GetGaugeVec(key).DeletePartialMatch(map[string]string{"Framework": name})
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FrameworkReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&argusiov1alpha1.Framework{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Controls being created, deleted or relabelled change which of them a Framework selects.
+		Watches(&argusiov1alpha1.Control{},
+			handler.EnqueueRequestsFromMapFunc(mapper.All(r.Client, &argusiov1alpha1.FrameworkList{})),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})),
+		).
+		// ComponentControl status changes may change the compliance of every Framework.
+		Watches(&argusiov1alpha1.ComponentControl{},
+			handler.EnqueueRequestsFromMapFunc(mapper.All(r.Client, &argusiov1alpha1.FrameworkList{})),
+		).
+		WithOptions(opts).
+		Complete(r)
+}
+
+func (r *FrameworkReconciler) notReady(ctx context.Context, res *argusiov1alpha1.Framework, err error) error {
+	return conditions.NotReady(ctx, r.Client, r.Recorder, res, &res.Status.Conditions, err)
+}
@@ -0,0 +1,124 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/componentcontrol"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ControlLabel returns the value of the argus.io/Control label of the objects of a Control.
+func ControlLabel(code, version string) string {
+	return fmt.Sprintf("%v_%v", code, version)
+}
+
+// SelectControls returns the Controls of a Framework, as the values of their argus.io/Control label, sorted.
+// A Control belongs to the Framework when ControlRefs lists its code and version, or when it lives in
+// the Framework namespace and matches ControlSelector.
+func SelectControls(res *argusiov1alpha1.Framework, Controls []argusiov1alpha1.Control) ([]string, error) {
+	selector := labels.Nothing()
+	if res.Spec.ControlSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(res.Spec.ControlSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid controlSelector: %w", err)
+		}
+	}
+	refs := map[string]bool{}
+	for _, ref := range res.Spec.ControlRefs {
+		refs[ControlLabel(ref.Code, ref.Version)] = true
+	}
+	seen := map[string]bool{}
+	selected := []string{}
+	for _, Control := range Controls {
+		name := ControlLabel(Control.Spec.Definition.Code, Control.Spec.Definition.Version)
+		matches := Control.Namespace == res.Namespace && selector.Matches(labels.Set(Control.Labels))
+		if (refs[name] || matches) && !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// Compliance returns the compliance of every Component of the Framework namespace with the given Controls, by Component name.
+// Only the ComponentControls of these Controls count, so Components none of them apply to are left out.
+// A Component is compliant when every Control applying to it is implemented or waived.
+func Compliance(res *argusiov1alpha1.Framework, controls []string, ComponentControls []argusiov1alpha1.ComponentControl) map[string]*argusiov1alpha1.FrameworkComponentCompliance {
+	inFramework := map[string]bool{}
+	for _, control := range controls {
+		inFramework[control] = true
+	}
+	components := map[string]*argusiov1alpha1.FrameworkComponentCompliance{}
+	for _, ComponentControl := range ComponentControls {
+		// Components are named after their label only, which is unique within a namespace.
+		if ComponentControl.Namespace != res.Namespace || !inFramework[ComponentControl.Labels["argus.io/Control"]] {
+			continue
+		}
+		name, ok := ComponentControl.Labels["argus.io/Component"]
+		if !ok {
+			continue
+		}
+		compliance, ok := components[name]
+		if !ok {
+			compliance = &argusiov1alpha1.FrameworkComponentCompliance{}
+			components[name] = compliance
+		}
+		compliance.TotalControls = compliance.TotalControls + 1
+		if componentcontrol.Implemented(ComponentControl) {
+			compliance.ImplementedControls = compliance.ImplementedControls + 1
+		} else if ComponentControl.Status.Waived {
+			compliance.WaivedControls = compliance.WaivedControls + 1
+		}
+	}
+	for _, compliance := range components {
+		compliance.Compliant = compliance.TotalControls == compliance.ImplementedControls+compliance.WaivedControls
+	}
+	return components
+}
+
+// CountCompliant returns how many of the Components are compliant.
+func CountCompliant(components map[string]*argusiov1alpha1.FrameworkComponentCompliance) int {
+	compliant := 0
+	for _, compliance := range components {
+		if compliance.Compliant {
+			compliant = compliant + 1
+		}
+	}
+	return compliant
+}
+
+// Validate checks that a Framework lists Controls with a valid controlSelector,
+// and that a Control exists for each of its controlRefs.
+func Validate(ctx context.Context, cl client.Reader, res *argusiov1alpha1.Framework) error {
+	if len(res.Spec.ControlRefs) == 0 && res.Spec.ControlSelector == nil {
+		return fmt.Errorf("one of controlRefs or controlSelector is required")
+	}
+	if res.Spec.ControlSelector != nil {
+		_, err := metav1.LabelSelectorAsSelector(res.Spec.ControlSelector)
+		if err != nil {
+			return fmt.Errorf("invalid controlSelector: %w", err)
+		}
+	}
+	ControlList := argusiov1alpha1.ControlList{}
+	err := cl.List(ctx, &ControlList)
+	if err != nil {
+		return fmt.Errorf("could not list Controls: %w", err)
+	}
+	existing := map[string]bool{}
+	for _, Control := range ControlList.Items {
+		existing[ControlLabel(Control.Spec.Definition.Code, Control.Spec.Definition.Version)] = true
+	}
+	for i, ref := range res.Spec.ControlRefs {
+		if !existing[ControlLabel(ref.Code, ref.Version)] {
+			return fmt.Errorf("controlRefs[%v]: no Control with code '%v' and version '%v'", i, ref.Code, ref.Version)
+		}
+	}
+	return nil
+}
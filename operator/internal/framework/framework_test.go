@@ -0,0 +1,236 @@
+package framework
+
+import (
+	"context"
+	"testing"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSelectControls(t *testing.T) {
+	t.Parallel()
+	controls := []argusiov1alpha1.Control{
+		makeControl("CTRL-02", "1", map[string]string{"level": "high"}),
+		makeControl("CTRL-01", "1", map[string]string{"level": "high"}),
+		makeControl("CTRL-01", "2", map[string]string{"level": "low"}),
+		makeControl("CTRL-03", "1", map[string]string{"level": "high"}, func(c *argusiov1alpha1.Control) {
+			c.Namespace = "other"
+		}),
+	}
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.Framework
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "referenced Controls",
+			res:      makeFramework(),
+			expected: []string{"CTRL-01_1"},
+		},
+		{
+			name: "referenced Control in another namespace",
+			res: makeFramework(func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlRefs = []argusiov1alpha1.AssessmentControlDefinition{{Code: "CTRL-03", Version: "1"}}
+			}),
+			expected: []string{"CTRL-03_1"},
+		},
+		{
+			name:     "selected Controls",
+			res:      makeFramework(WithControlSelector(map[string]string{"level": "high"})),
+			expected: []string{"CTRL-01_1", "CTRL-02_1"},
+		},
+		{
+			name: "referenced and selected Controls",
+			res: makeFramework(WithControlSelector(map[string]string{"level": "low"}), func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlRefs = []argusiov1alpha1.AssessmentControlDefinition{{Code: "CTRL-01", Version: "1"}, {Code: "CTRL-01", Version: "2"}}
+			}),
+			expected: []string{"CTRL-01_1", "CTRL-01_2"},
+		},
+		{
+			name: "no Control",
+			res: makeFramework(func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlRefs = []argusiov1alpha1.AssessmentControlDefinition{{Code: "CTRL-09", Version: "1"}}
+			}),
+			expected: []string{},
+		},
+		{
+			name: "invalid selector",
+			res: makeFramework(func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "level", Operator: "Bogus"}}}
+			}),
+			expectedError: "invalid controlSelector",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			selected, err := SelectControls(testCase.res, controls)
+			if testCase.expectedError != "" {
+				require.ErrorContains(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, selected)
+		})
+	}
+}
+
+func TestCompliance(t *testing.T) {
+	t.Parallel()
+	ComponentControls := []argusiov1alpha1.ComponentControl{
+		makeComponentControl("vm", "CTRL-01_1", 2, 2, false),
+		makeComponentControl("vm", "CTRL-02_1", 2, 1, true),
+		makeComponentControl("db", "CTRL-01_1", 1, 0, false),
+		makeComponentControl("db", "CTRL-02_1", 0, 0, false),
+		makeComponentControl("app", "CTRL-09_1", 1, 0, false),
+	}
+	// A Component of another namespace, named as one of the Framework namespace, is left out.
+	other := makeComponentControl("vm", "CTRL-01_1", 1, 0, false)
+	other.Namespace = "other"
+	ComponentControls = append(ComponentControls, other)
+	res := makeFramework()
+	components := Compliance(res, []string{"CTRL-01_1", "CTRL-02_1"}, ComponentControls)
+	assert.Equal(t, map[string]*argusiov1alpha1.FrameworkComponentCompliance{
+		"vm": {TotalControls: 2, ImplementedControls: 1, WaivedControls: 1, Compliant: true},
+		"db": {TotalControls: 2},
+	}, components)
+	assert.Equal(t, 1, CountCompliant(components))
+	assert.Equal(t, 0, CountCompliant(Compliance(res, []string{}, ComponentControls)))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.NoError(t, err)
+	testCases := []struct {
+		name          string
+		res           *argusiov1alpha1.Framework
+		expectedError string
+	}{
+		{
+			name: "valid framework",
+			res:  makeFramework(),
+		},
+		{
+			name: "valid selector",
+			res:  makeFramework(WithControlSelector(map[string]string{"level": "high"})),
+		},
+		{
+			name: "no Controls",
+			res: makeFramework(func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlRefs = nil
+			}),
+			expectedError: "one of controlRefs or controlSelector is required",
+		},
+		{
+			name: "invalid selector",
+			res: makeFramework(func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "level", Operator: "Bogus"}}}
+			}),
+			expectedError: "invalid controlSelector",
+		},
+		{
+			name: "missing Control",
+			res: makeFramework(func(f *argusiov1alpha1.Framework) {
+				f.Spec.ControlRefs = append(f.Spec.ControlRefs, argusiov1alpha1.AssessmentControlDefinition{Code: "CTRL-01", Version: "2"})
+			}),
+			expectedError: "controlRefs[1]: no Control with code 'CTRL-01' and version '2'",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			control := makeControl("CTRL-01", "1", nil)
+			cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(&control).Build()
+			err := Validate(context.Background(), cl, testCase.res)
+			if testCase.expectedError != "" {
+				require.ErrorContains(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// Helpers
+
+type frameworkMutationFn func(*argusiov1alpha1.Framework)
+
+func WithControlSelector(labels map[string]string) frameworkMutationFn {
+	return func(res *argusiov1alpha1.Framework) {
+		res.Spec.ControlRefs = nil
+		res.Spec.ControlSelector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+}
+
+func makeFramework(f ...frameworkMutationFn) *argusiov1alpha1.Framework {
+	res := &argusiov1alpha1.Framework{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baseline",
+			Namespace: "default",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Framework",
+			APIVersion: "argus.io/v1alpha1",
+		},
+		Spec: argusiov1alpha1.FrameworkSpec{
+			Title:       "Baseline",
+			Version:     "1.0.0",
+			ControlRefs: []argusiov1alpha1.AssessmentControlDefinition{{Code: "CTRL-01", Version: "1"}},
+		},
+	}
+	for _, fn := range f {
+		fn(res)
+	}
+	return res
+}
+
+type controlMutationFn func(*argusiov1alpha1.Control)
+
+func makeControl(code, version string, labels map[string]string, f ...controlMutationFn) argusiov1alpha1.Control {
+	res := argusiov1alpha1.Control{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ControlLabel(code, version),
+			Namespace: "default",
+			Labels:    labels,
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Control",
+			APIVersion: "argus.io/v1alpha1",
+		},
+		Spec: argusiov1alpha1.ControlSpec{
+			Definition: argusiov1alpha1.ControlDefinition{Code: code, Version: version},
+		},
+	}
+	for _, fn := range f {
+		fn(&res)
+	}
+	return res
+}
+
+func makeComponentControl(component, control string, total, valid int, waived bool) argusiov1alpha1.ComponentControl {
+	return argusiov1alpha1.ComponentControl{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component + "-" + control,
+			Namespace: "default",
+			Labels: map[string]string{
+				"argus.io/Component": component,
+				"argus.io/Control":   control,
+			},
+		},
+		Status: argusiov1alpha1.ComponentControlStatus{
+			TotalAssessments: total,
+			ValidAssessments: valid,
+			Waived:           waived,
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package mapper
+
+import (
+	"context"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ByLabels enqueues the objects of the list type, in the namespace of the mapped object,
+// that carry the same value as the mapped object for every one of keys.
+// Objects missing any of the labels are not mapped.
+func ByLabels(cl client.Client, list client.ObjectList, keys ...string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		matching := client.MatchingLabels{}
+		for _, key := range keys {
+			value, ok := obj.GetLabels()[key]
+			if !ok {
+				return nil
+			}
+			matching[key] = value
+		}
+		items := list.DeepCopyObject().(client.ObjectList)
+		err := cl.List(ctx, items, client.InNamespace(obj.GetNamespace()), matching)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "could not list objects to map", "object", obj.GetName())
+			return nil
+		}
+		return fromList(items)
+	}
+}
+
+// ByLabelName enqueues the object named after the value of label, in the namespace of the mapped object.
+func ByLabelName(label string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		name, ok := obj.GetLabels()[label]
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: obj.GetNamespace()}}}
+	}
+}
+
+// All enqueues every object of the list type.
+func All(cl client.Client, list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		items := list.DeepCopyObject().(client.ObjectList)
+		err := cl.List(ctx, items)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "could not list objects to map", "object", obj.GetName())
+			return nil
+		}
+		return fromList(items)
+	}
+}
+
+// ByProviderRef enqueues the ComponentAttestations whose providerRef is the mapped AttestationProvider.
+func ByProviderRef(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		items := argusiov1alpha1.ComponentAttestationList{}
+		err := cl.List(ctx, &items)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "could not list objects to map", "object", obj.GetName())
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, item := range items.Items {
+			if item.Spec.ProviderRef.Name == obj.GetName() && item.Spec.ProviderRef.Namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+				})
+			}
+		}
+		return requests
+	}
+}
+
+// Requests converts a list of names into reconcile requests.
+func Requests(names []argusiov1alpha1.NamespacedName) []reconcile.Request {
+	requests := []reconcile.Request{}
+	for _, name := range names {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: name.Name, Namespace: name.Namespace},
+		})
+	}
+	return requests
+}
+
+func fromList(list client.ObjectList) []reconcile.Request {
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, o := range objs {
+		obj, ok := o.(client.Object)
+		if !ok {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()},
+		})
+	}
+	return requests
+}
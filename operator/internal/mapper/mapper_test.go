@@ -0,0 +1,168 @@
+package mapper
+
+import (
+	"context"
+	"testing"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestByLabels(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	testCases := []struct {
+		name           string
+		obj            client.Object
+		cl             client.Client
+		expectedOutput []reconcile.Request
+	}{
+		{
+			name: "missing label",
+			obj: makeComponentAttestation(map[string]string{
+				"argus.io/Assessment": "assessment",
+			}),
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).Build(),
+		},
+		{
+			name: "fail listing",
+			obj: makeComponentAttestation(map[string]string{
+				"argus.io/Assessment": "assessment",
+				"argus.io/Component":  "component",
+			}),
+			cl: fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+		},
+		{
+			name: "matching labels",
+			obj: makeComponentAttestation(map[string]string{
+				"argus.io/Assessment":  "assessment",
+				"argus.io/Component":   "component",
+				"argus.io/attestation": "attestation",
+			}),
+			cl: fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+				makeComponentAssessment("match", "test", map[string]string{
+					"argus.io/Assessment": "assessment",
+					"argus.io/Component":  "component",
+				}),
+				makeComponentAssessment("other-component", "test", map[string]string{
+					"argus.io/Assessment": "assessment",
+					"argus.io/Component":  "other",
+				}),
+				makeComponentAssessment("other-namespace", "other", map[string]string{
+					"argus.io/Assessment": "assessment",
+					"argus.io/Component":  "component",
+				}),
+			).Build(),
+			expectedOutput: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "match", Namespace: "test"}},
+			},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			fn := ByLabels(testCase.cl, &argusiov1alpha1.ComponentAssessmentList{}, "argus.io/Assessment", "argus.io/Component")
+			output := fn(context.Background(), testCase.obj)
+			assert.Equal(t, testCase.expectedOutput, output)
+		})
+	}
+}
+
+func TestByLabelName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		obj            client.Object
+		expectedOutput []reconcile.Request
+	}{
+		{
+			name: "missing label",
+			obj:  makeComponentAttestation(map[string]string{}),
+		},
+		{
+			name: "named by label",
+			obj:  makeComponentAttestation(map[string]string{"argus.io/Component": "component"}),
+			expectedOutput: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "component", Namespace: "test"}},
+			},
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			output := ByLabelName("argus.io/Component")(context.Background(), testCase.obj)
+			assert.Equal(t, testCase.expectedOutput, output)
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+		makeComponentAssessment("one", "test", nil),
+		makeComponentAssessment("two", "other", nil),
+	).Build()
+	output := All(cl, &argusiov1alpha1.ComponentAssessmentList{})(context.Background(), makeComponentAttestation(nil))
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "one", Namespace: "test"}},
+		{NamespacedName: types.NamespacedName{Name: "two", Namespace: "other"}},
+	}, output)
+}
+
+func TestByProviderRef(t *testing.T) {
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.Nil(t, err)
+	withProvider := func(name, namespace, providerName, providerNamespace string) *argusiov1alpha1.ComponentAttestation {
+		res := makeComponentAttestation(nil)
+		res.Name = name
+		res.Namespace = namespace
+		res.Spec.ProviderRef = argusiov1alpha1.AttestationProviderRef{Name: providerName, Namespace: providerNamespace}
+		return res
+	}
+	cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+		withProvider("same-namespace", "test", "provider", "test"),
+		withProvider("other-namespace", "other", "provider", "test"),
+		withProvider("other-provider", "test", "other", "test"),
+		withProvider("same-name", "test", "provider", "other"),
+	).Build()
+	provider := &argusiov1alpha1.AttestationProvider{ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "test"}}
+	output := ByProviderRef(cl)(context.Background(), provider)
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "same-namespace", Namespace: "test"}},
+		{NamespacedName: types.NamespacedName{Name: "other-namespace", Namespace: "other"}},
+	}, output)
+}
+
+// Helpers
+
+func makeComponentAttestation(labels map[string]string) *argusiov1alpha1.ComponentAttestation {
+	return &argusiov1alpha1.ComponentAttestation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+			Labels:    labels,
+		},
+	}
+}
+
+func makeComponentAssessment(name, namespace string, labels map[string]string) *argusiov1alpha1.ComponentAssessment {
+	return &argusiov1alpha1.ComponentAssessment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
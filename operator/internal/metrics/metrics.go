@@ -5,70 +5,95 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
-var AssessmentLabels = []string{"Component", "Assessment", "Control"}
-var ControlLabels = []string{"Component", "Control"}
-var ComponentLabels = []string{"Component"}
+// Subsystem prefixes the name of every metric.
+const Subsystem = "argus"
+
+// Label names.
+const (
+	LabelComponent        = "Component"
+	LabelAssessment       = "Assessment"
+	LabelControl          = "Control"
+	LabelFramework        = "Framework"
+	LabelFrameworkVersion = "FrameworkVersion"
+)
+
+var AssessmentLabels = []string{LabelComponent, LabelAssessment, LabelControl}
+var ControlLabels = []string{LabelComponent, LabelControl}
+var ComponentLabels = []string{LabelComponent}
+var FrameworkLabels = []string{LabelFramework, LabelFrameworkVersion}
+var FrameworkComponentLabels = []string{LabelFramework, LabelFrameworkVersion, LabelComponent}
 
 const (
 	AttestationTotalKey = "attestations_total"
 	AttestationValidKey = "attestations_valid"
 	AssessmentTotalKey  = "Assessments_total"
 	AssessmentValidKey  = "Assessments_valid"
+	AssessmentWaivedKey = "Assessments_waived"
 	ControlTotalKey     = "Controls_total"
 	ControlValidKey     = "Controls_valid"
+	ControlWaivedKey    = "Controls_waived"
+
+	FrameworkComponentsTotalKey = "Framework_Components_total"
+	FrameworkComponentsValidKey = "Framework_Components_valid"
+	FrameworkControlsTotalKey   = "Framework_Controls_total"
+	FrameworkControlsValidKey   = "Framework_Controls_valid"
 )
 
+// Definition describes a gauge of the operator. Kind is the kind of the objects setting its series.
+type Definition struct {
+	Key    string
+	Help   string
+	Labels []string
+	Kind   string
+}
+
+// Name is the name the metric is exposed with.
+func (d Definition) Name() string {
+	return prometheus.BuildFQName("", Subsystem, d.Key)
+}
+
+// Definitions lists every metric of the operator.
+var Definitions = []Definition{
+	{Key: AttestationTotalKey, Help: "Total number of Attestations", Labels: AssessmentLabels, Kind: "ComponentAssessment"},
+	{Key: AttestationValidKey, Help: "Number of valid Attestations", Labels: AssessmentLabels, Kind: "ComponentAssessment"},
+	{Key: AssessmentTotalKey, Help: "Total number of Assessments", Labels: ControlLabels, Kind: "ComponentControl"},
+	{Key: AssessmentValidKey, Help: "Number of valid Assessments", Labels: ControlLabels, Kind: "ComponentControl"},
+	{Key: AssessmentWaivedKey, Help: "Number of Assessments waived by a ComplianceException", Labels: ControlLabels, Kind: "ComponentControl"},
+	{Key: ControlTotalKey, Help: "Total number of Controls", Labels: ComponentLabels, Kind: "Component"},
+	{Key: ControlValidKey, Help: "Number of valid Controls", Labels: ComponentLabels, Kind: "Component"},
+	{Key: ControlWaivedKey, Help: "Number of Controls waived by a ComplianceException", Labels: ComponentLabels, Kind: "Component"},
+	{Key: FrameworkComponentsTotalKey, Help: "Total number of Components a Framework applies to", Labels: FrameworkLabels, Kind: "Framework"},
+	{Key: FrameworkComponentsValidKey, Help: "Number of Components compliant with a Framework", Labels: FrameworkLabels, Kind: "Framework"},
+	{Key: FrameworkControlsTotalKey, Help: "Total number of Controls of a Framework applicable to a Component", Labels: FrameworkComponentLabels, Kind: "Framework"},
+	{Key: FrameworkControlsValidKey, Help: "Number of Controls of a Framework a Component implements or has waived", Labels: FrameworkComponentLabels, Kind: "Framework"},
+}
+
+// Lookup returns the definition of the metric with the given key.
+func Lookup(key string) (Definition, bool) {
+	for _, d := range Definitions {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
 var gaugeVecMetrics = map[string]*prometheus.GaugeVec{}
 
 func SetUpMetrics() {
 	// Only register once
-	if len(gaugeVecMetrics) == 6 {
+	if len(gaugeVecMetrics) == len(Definitions) {
 		return
 	}
 	// Obtain the prometheus metrics and register
-	attestationsTotal := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Subsystem: "argus",
-		Name:      "attestations_total",
-		Help:      "Total number of Attestations",
-	}, AssessmentLabels)
-	attestationsValid := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Subsystem: "argus",
-		Name:      "attestations_valid",
-		Help:      "Total number of Attestations",
-	}, AssessmentLabels)
-	AssessmentsTotal := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Subsystem: "argus",
-		Name:      "Assessments_total",
-		Help:      "Total number of Assessments",
-	}, ControlLabels)
-	// Obtain the prometheus metrics and register
-	AssessmentsValid := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Subsystem: "argus",
-		Name:      "Assessments_valid",
-		Help:      "Number of valid Assessments",
-	}, ControlLabels)
-	ControlsTotal := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Subsystem: "argus",
-		Name:      "Controls_total",
-		Help:      "Total number of Controls",
-	}, ComponentLabels)
-	ControlsValid := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Subsystem: "argus",
-		Name:      "Controls_valid",
-		Help:      "Number of valid Controls",
-	}, ComponentLabels)
-	metrics.Registry.MustRegister(
-		attestationsTotal, attestationsValid,
-		AssessmentsTotal, AssessmentsValid,
-		ControlsTotal, ControlsValid)
-
-	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
-		AttestationTotalKey: attestationsTotal,
-		AttestationValidKey: attestationsValid,
-		AssessmentTotalKey:  AssessmentsTotal,
-		AssessmentValidKey:  AssessmentsValid,
-		ControlTotalKey:     ControlsTotal,
-		ControlValidKey:     ControlsValid,
+	for _, d := range Definitions {
+		v := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: Subsystem,
+			Name:      d.Key,
+			Help:      d.Help,
+		}, d.Labels)
+		metrics.Registry.MustRegister(v)
+		gaugeVecMetrics[d.Key] = v
 	}
 }
 
@@ -0,0 +1,414 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/ContainerSolutions/argus/operator/internal/metrics"
+)
+
+// DefaultSLOTarget is the share of applicable Controls a Component is expected to implement.
+const DefaultSLOTarget = 0.99
+
+// Compliance recording rules of every Component.
+const (
+	ComplianceRecord    = "argus:component_compliance:ratio"
+	NonComplianceRecord = "argus:component_noncompliance:ratio_avg"
+)
+
+// windows are the ranges non compliance is averaged over by the burn rate alerts.
+var windows = []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"}
+
+// burnRate alerts when the error budget burns factor times faster than the SLO allows,
+// over both a long and a short window, as in the multiwindow, multi-burn-rate alerts of the SRE workbook.
+type burnRate struct {
+	long     string
+	short    string
+	factor   float64
+	severity string
+}
+
+var burnRates = []burnRate{
+	{long: "1h", short: "5m", factor: 14.4, severity: "critical"},
+	{long: "6h", short: "30m", factor: 6, severity: "critical"},
+	{long: "1d", short: "2h", factor: 3, severity: "warning"},
+	{long: "3d", short: "6h", factor: 1, severity: "warning"},
+}
+
+// name returns the name of the metric with the given key. Every expression is built from the
+// metric definitions, so the generated rules and dashboards can not drift from the exposed metrics.
+func name(key string) (string, error) {
+	d, ok := metrics.Lookup(key)
+	if !ok {
+		return "", fmt.Errorf("no metric definition with key '%v'", key)
+	}
+	return d.Name(), nil
+}
+
+// nonComplianceRecord returns the name of the non compliance recorded over window.
+func nonComplianceRecord(window string) string {
+	return NonComplianceRecord + window
+}
+
+// complianceExpr returns the ratio of the Controls each Component implements or has waived.
+func complianceExpr() (string, error) {
+	valid, err := name(metrics.ControlValidKey)
+	if err != nil {
+		return "", err
+	}
+	waived, err := name(metrics.ControlWaivedKey)
+	if err != nil {
+		return "", err
+	}
+	total, err := name(metrics.ControlTotalKey)
+	if err != nil {
+		return "", err
+	}
+	by := strings.Join(metrics.ComponentLabels, ", ")
+	return fmt.Sprintf("(sum by (%v) (%v) + sum by (%v) (%v)) / sum by (%v) (%v)", by, valid, by, waived, by, total), nil
+}
+
+// Rule is a Prometheus recording or alerting rule.
+type Rule struct {
+	Record      string            `json:"record,omitempty"`
+	Alert       string            `json:"alert,omitempty"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RuleGroup is a named group of rules.
+type RuleGroup struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// RuleGroups returns the recording rules of the compliance of every Component, along with
+// burn rate alerts on that compliance for the given SLO target.
+func RuleGroups(target float64) ([]RuleGroup, error) {
+	if target <= 0 || target >= 1 {
+		return nil, fmt.Errorf("SLO target must be between 0 and 1, got %v", target)
+	}
+	compliance, err := complianceExpr()
+	if err != nil {
+		return nil, err
+	}
+	records := []Rule{{Record: ComplianceRecord, Expr: compliance}}
+	for _, w := range windows {
+		records = append(records, Rule{
+			Record: nonComplianceRecord(w),
+			Expr:   fmt.Sprintf("1 - avg_over_time(%v[%v])", ComplianceRecord, w),
+		})
+	}
+	budget := 1 - target
+	component := fmt.Sprintf("{{ $labels.%v }}", metrics.LabelComponent)
+	alerts := []Rule{}
+	for _, b := range burnRates {
+		threshold := formatFloat(b.factor * budget)
+		alerts = append(alerts, Rule{
+			Alert: "ArgusComplianceBudgetBurn",
+			Expr: fmt.Sprintf("%v > %v and %v > %v",
+				nonComplianceRecord(b.long), threshold, nonComplianceRecord(b.short), threshold),
+			Labels: map[string]string{
+				"severity":    b.severity,
+				"long_window": b.long,
+			},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("Component %v burns its compliance error budget too fast", component),
+				"description": fmt.Sprintf("Component %v missed %v of its Controls over %v, "+
+					"burning its error budget %vx faster than a %v%% compliance SLO allows.",
+					component, "{{ $value | humanizePercentage }}", b.long, formatFloat(b.factor), formatFloat(target*100)),
+			},
+		})
+	}
+	return []RuleGroup{
+		{Name: "argus-compliance.rules", Rules: records},
+		{Name: "argus-compliance.alerts", Rules: alerts},
+	}, nil
+}
+
+// PrometheusRule returns the rules of RuleGroups as a PrometheusRule manifest of the Prometheus operator.
+func PrometheusRule(target float64) ([]byte, error) {
+	groups, err := RuleGroups(target)
+	if err != nil {
+		return nil, err
+	}
+	rule := map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "PrometheusRule",
+		"metadata": map[string]interface{}{
+			"name":      "compliance-rules",
+			"namespace": "system",
+			"labels": map[string]string{
+				"control-plane":                "controller-manager",
+				"app.kubernetes.io/name":       "prometheusrule",
+				"app.kubernetes.io/instance":   "compliance-rules",
+				"app.kubernetes.io/component":  "metrics",
+				"app.kubernetes.io/created-by": "operator",
+				"app.kubernetes.io/part-of":    "operator",
+				"app.kubernetes.io/managed-by": "kustomize",
+			},
+		},
+		"spec": map[string]interface{}{
+			"groups": groups,
+		},
+	}
+	out, err := yaml.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(generatedHeader("#")), out...), nil
+}
+
+func generatedHeader(comment string) string {
+	return comment + " Code generated by hack/monitoring from internal/metrics. DO NOT EDIT.\n"
+}
+
+func formatFloat(f float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", f), "0"), ".")
+}
+
+// Dashboard is a Grafana dashboard.
+type Dashboard struct {
+	Title         string     `json:"title"`
+	UID           string     `json:"uid"`
+	Description   string     `json:"description"`
+	Tags          []string   `json:"tags"`
+	Editable      bool       `json:"editable"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a dashboard variable, filtering the series of every panel.
+type Variable struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label"`
+	Type       string      `json:"type"`
+	Query      string      `json:"query,omitempty"`
+	Datasource *Datasource `json:"datasource,omitempty"`
+	Multi      bool        `json:"multi,omitempty"`
+	IncludeAll bool        `json:"includeAll,omitempty"`
+	AllValue   string      `json:"allValue,omitempty"`
+	Refresh    int         `json:"refresh,omitempty"`
+}
+
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type Target struct {
+	RefID        string     `json:"refId"`
+	Expr         string     `json:"expr"`
+	LegendFormat string     `json:"legendFormat,omitempty"`
+	Datasource   Datasource `json:"datasource"`
+}
+
+type FieldConfig struct {
+	Defaults FieldDefaults `json:"defaults"`
+}
+
+type FieldDefaults struct {
+	Unit string   `json:"unit,omitempty"`
+	Min  *float64 `json:"min,omitempty"`
+	Max  *float64 `json:"max,omitempty"`
+}
+
+// Panel is a dashboard panel. Rows only set their title and position.
+type Panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	GridPos     GridPos      `json:"gridPos"`
+	Datasource  *Datasource  `json:"datasource,omitempty"`
+	Targets     []Target     `json:"targets,omitempty"`
+	FieldConfig *FieldConfig `json:"fieldConfig,omitempty"`
+}
+
+var datasource = Datasource{Type: "prometheus", UID: "${datasource}"}
+
+// filters are the labels the dashboard variables of the same name filter on.
+var filters = []string{metrics.LabelComponent, metrics.LabelFramework}
+
+// selector returns the label matchers of the dashboard variables that apply to labels.
+func selector(labels []string) string {
+	matchers := []string{}
+	for _, f := range filters {
+		for _, l := range labels {
+			if l == f {
+				matchers = append(matchers, fmt.Sprintf("%v=~\"$%v\"", f, f))
+			}
+		}
+	}
+	if len(matchers) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+func legend(labels []string) string {
+	parts := []string{}
+	for _, l := range labels {
+		parts = append(parts, fmt.Sprintf("{{%v}}", l))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// query returns the expression plotting a metric.
+func query(d metrics.Definition) Target {
+	return Target{Expr: d.Name() + selector(d.Labels), LegendFormat: legend(d.Labels)}
+}
+
+// panelBuilder lays panels out, two per row.
+type panelBuilder struct {
+	panels []Panel
+	y      int
+	x      int
+}
+
+func (b *panelBuilder) row(title string) {
+	if b.x != 0 {
+		b.x = 0
+		b.y += 8
+	}
+	b.panels = append(b.panels, Panel{ID: len(b.panels) + 1, Type: "row", Title: title, GridPos: GridPos{H: 1, W: 24, Y: b.y}})
+	b.y++
+}
+
+func (b *panelBuilder) panel(title, description, unit string, targets ...Target) {
+	for i := range targets {
+		targets[i].RefID = string(rune('A' + i))
+		targets[i].Datasource = datasource
+	}
+	ds := datasource
+	p := Panel{
+		ID:          len(b.panels) + 1,
+		Type:        "timeseries",
+		Title:       title,
+		Description: description,
+		GridPos:     GridPos{H: 8, W: 12, X: b.x, Y: b.y},
+		Datasource:  &ds,
+		Targets:     targets,
+		FieldConfig: &FieldConfig{Defaults: FieldDefaults{Unit: unit}},
+	}
+	if unit == "percentunit" {
+		min, max := 0.0, 1.0
+		p.FieldConfig.Defaults.Min = &min
+		p.FieldConfig.Defaults.Max = &max
+	}
+	b.panels = append(b.panels, p)
+	if b.x == 0 {
+		b.x = 12
+		return
+	}
+	b.x = 0
+	b.y += 8
+}
+
+// kinds orders the rows of the dashboard.
+var kinds = []string{"Framework", "Component", "ComponentControl", "ComponentAssessment"}
+
+var kindTitles = map[string]string{
+	"Framework":           "Frameworks",
+	"Component":           "Components",
+	"ComponentControl":    "Component Controls",
+	"ComponentAssessment": "Component Assessments",
+}
+
+// NewDashboard returns a dashboard with the compliance SLO of every Component, followed by
+// a panel for every metric definition, grouped by the kind of object the metric belongs to.
+func NewDashboard(target float64) (*Dashboard, error) {
+	controls, err := name(metrics.ControlTotalKey)
+	if err != nil {
+		return nil, err
+	}
+	frameworks, err := name(metrics.FrameworkComponentsTotalKey)
+	if err != nil {
+		return nil, err
+	}
+	b := &panelBuilder{}
+	b.row("Compliance SLO")
+	sel := selector(metrics.ComponentLabels)
+	b.panel("Component compliance", "Ratio of the applicable Controls each Component implements or has waived, against the SLO target.", "percentunit",
+		Target{Expr: ComplianceRecord + sel, LegendFormat: legend(metrics.ComponentLabels)},
+		Target{Expr: fmt.Sprintf("vector(%v)", formatFloat(target)), LegendFormat: "SLO target"},
+	)
+	burn := []Target{}
+	for _, w := range []string{"1h", "6h", "1d", "3d"} {
+		burn = append(burn, Target{
+			Expr:         fmt.Sprintf("%v%v / %v", nonComplianceRecord(w), sel, formatFloat(1-target)),
+			LegendFormat: legend(metrics.ComponentLabels) + " " + w,
+		})
+	}
+	b.panel("Error budget burn rate", "How many times faster than the SLO allows each Component burns its error budget.", "short", burn...)
+	for _, kind := range kinds {
+		defs := []metrics.Definition{}
+		for _, d := range metrics.Definitions {
+			if d.Kind == kind {
+				defs = append(defs, d)
+			}
+		}
+		if len(defs) == 0 {
+			continue
+		}
+		b.row(kindTitles[kind])
+		for _, d := range defs {
+			b.panel(d.Name(), d.Help, "short", query(d))
+		}
+	}
+	return &Dashboard{
+		Title:         "Argus compliance",
+		UID:           "argus-compliance",
+		Description:   "Compliance of the Components, Controls and attestations managed by argus.",
+		Tags:          []string{"argus", "compliance"},
+		Editable:      true,
+		SchemaVersion: 38,
+		Refresh:       "1m",
+		Time:          TimeRange{From: "now-7d", To: "now"},
+		Templating: Templating{List: []Variable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+			{Name: metrics.LabelComponent, Label: "Component", Type: "query", Datasource: &datasource, Multi: true, IncludeAll: true, AllValue: ".*", Refresh: 2,
+				Query: fmt.Sprintf("label_values(%v, %v)", controls, metrics.LabelComponent)},
+			{Name: metrics.LabelFramework, Label: "Framework", Type: "query", Datasource: &datasource, Multi: true, IncludeAll: true, AllValue: ".*", Refresh: 2,
+				Query: fmt.Sprintf("label_values(%v, %v)", frameworks, metrics.LabelFramework)},
+		}},
+		Panels: b.panels,
+	}, nil
+}
+
+// DashboardJSON returns the dashboard of NewDashboard, ready to import into Grafana.
+func DashboardJSON(target float64) ([]byte, error) {
+	d, err := NewDashboard(target)
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,186 @@
+package monitoring
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ContainerSolutions/argus/operator/internal/metrics"
+	"github.com/ContainerSolutions/argus/operator/internal/utils"
+)
+
+var metricName = regexp.MustCompile(`\bargus_[A-Za-z_]+`)
+
+func TestRuleGroups(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		target        float64
+		expectedError string
+	}{
+		{
+			name:   "valid target",
+			target: DefaultSLOTarget,
+		},
+		{
+			name:          "target of 1 leaves no error budget",
+			target:        1,
+			expectedError: "SLO target must be between 0 and 1, got 1",
+		},
+		{
+			name:          "negative target",
+			target:        -0.5,
+			expectedError: "SLO target must be between 0 and 1, got -0.5",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			groups, err := RuleGroups(testCase.target)
+			if testCase.expectedError != "" {
+				require.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			records := map[string]bool{}
+			for _, g := range groups {
+				for _, r := range g.Rules {
+					if r.Record != "" {
+						records[r.Record] = true
+					}
+					assertKnownMetrics(t, r.Expr)
+				}
+			}
+			for _, g := range groups {
+				for _, r := range g.Rules {
+					for _, used := range regexp.MustCompile(`argus:[a-z_:0-9]+`).FindAllString(r.Expr, -1) {
+						assert.True(t, records[used], "expression %q uses unknown record %q", r.Expr, used)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBurnRateThresholds(t *testing.T) {
+	t.Parallel()
+	groups, err := RuleGroups(0.95)
+	require.NoError(t, err)
+	exprs := []string{}
+	for _, r := range groups[1].Rules {
+		if r.Alert == "ArgusComplianceBudgetBurn" {
+			exprs = append(exprs, r.Expr)
+		}
+	}
+	assert.Equal(t, []string{
+		"argus:component_noncompliance:ratio_avg1h > 0.72 and argus:component_noncompliance:ratio_avg5m > 0.72",
+		"argus:component_noncompliance:ratio_avg6h > 0.3 and argus:component_noncompliance:ratio_avg30m > 0.3",
+		"argus:component_noncompliance:ratio_avg1d > 0.15 and argus:component_noncompliance:ratio_avg2h > 0.15",
+		"argus:component_noncompliance:ratio_avg3d > 0.05 and argus:component_noncompliance:ratio_avg6h > 0.05",
+	}, exprs)
+}
+
+func TestNewDashboard(t *testing.T) {
+	t.Parallel()
+	d, err := NewDashboard(DefaultSLOTarget)
+	require.NoError(t, err)
+	titles := map[string]bool{}
+	for _, p := range d.Panels {
+		titles[p.Title] = true
+		for _, target := range p.Targets {
+			assertKnownMetrics(t, target.Expr)
+		}
+	}
+	for _, def := range metrics.Definitions {
+		assert.True(t, titles[def.Name()], "no panel for metric %v", def.Name())
+	}
+	for _, v := range d.Templating.List {
+		assertKnownMetrics(t, v.Query)
+	}
+}
+
+// TestGenerated fails when the generated files are out of date. Run `make monitoring` to update them.
+func TestGenerated(t *testing.T) {
+	t.Parallel()
+	dashboard, err := DashboardJSON(DefaultSLOTarget)
+	require.NoError(t, err)
+	rules, err := PrometheusRule(DefaultSLOTarget)
+	require.NoError(t, err)
+	for path, expected := range map[string][]byte{
+		"../../config/grafana/argus-compliance.json": dashboard,
+		"../../config/prometheus/rules.yaml":         rules,
+	} {
+		actual, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), "%v is out of date, run `make monitoring`", path)
+	}
+
+	// The labels the rules and the dashboard group and filter on must survive the scrape of the ServiceMonitor.
+	groups, err := RuleGroups(DefaultSLOTarget)
+	require.NoError(t, err)
+	grouped := append([]string{}, filters...)
+	for _, g := range groups {
+		for _, r := range g.Rules {
+			for _, by := range groupBy.FindAllStringSubmatch(r.Expr, -1) {
+				for _, l := range strings.Split(by[1], ",") {
+					grouped = append(grouped, strings.TrimSpace(l))
+				}
+			}
+		}
+	}
+	assert.Contains(t, grouped, metrics.LabelComponent)
+	kept := keptLabels(t, "../../config/prometheus/monitor.yaml")
+	for _, l := range grouped {
+		assert.True(t, kept(l), "the ServiceMonitor overwrites the label %q the rules or the dashboard use", l)
+	}
+}
+
+// Helpers
+
+var groupBy = regexp.MustCompile(`by \(([^)]*)\)`)
+
+// targetLabels are the labels the Prometheus operator sets on every target of a ServiceMonitor.
+var targetLabels = []string{"namespace", "service", "pod", "container", "endpoint", "job", "instance"}
+
+// keptLabels returns whether the scrapes of the ServiceMonitor at path keep a label of the metrics.
+// Target labels overwrite the metric labels of the same name, unless an endpoint honors them.
+func keptLabels(t *testing.T, path string) func(string) bool {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	monitor := struct {
+		Spec struct {
+			Endpoints []struct {
+				HonorLabels bool `json:"honorLabels"`
+			} `json:"endpoints"`
+		} `json:"spec"`
+	}{}
+	require.NoError(t, yaml.Unmarshal(data, &monitor))
+	require.NotEmpty(t, monitor.Spec.Endpoints)
+	return func(label string) bool {
+		for _, e := range monitor.Spec.Endpoints {
+			if !e.HonorLabels && utils.Contains(targetLabels, label) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// assertKnownMetrics asserts that every metric expr uses is defined in internal/metrics.
+func assertKnownMetrics(t *testing.T, expr string) {
+	t.Helper()
+	names := map[string]bool{}
+	for _, d := range metrics.Definitions {
+		names[d.Name()] = true
+	}
+	for _, used := range metricName.FindAllString(expr, -1) {
+		assert.True(t, names[used], "expression %q uses unknown metric %q", expr, used)
+	}
+}
@@ -0,0 +1,439 @@
+package oscal
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/ContainerSolutions/argus/operator/internal/componentcontrol"
+	"github.com/ContainerSolutions/argus/operator/internal/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Version is the OSCAL version of the documents the operator writes.
+const Version = "1.1.2"
+
+// Namespace qualifies the names of the properties argus adds to OSCAL documents.
+const Namespace = "https://github.com/ContainerSolutions/argus"
+
+// AssessmentResultsDocument is an OSCAL assessment-results document, restricted to what the operator fills in.
+// See https://pages.nist.gov/OSCAL/reference/1.1.2/assessment-results/json-outline/ for the full model.
+type AssessmentResultsDocument struct {
+	AssessmentResults AssessmentResults `json:"assessment-results"`
+}
+
+type AssessmentResults struct {
+	UUID     string   `json:"uuid"`
+	Metadata Metadata `json:"metadata"`
+	ImportAP ImportAP `json:"import-ap"`
+	Results  []Result `json:"results"`
+}
+
+type Metadata struct {
+	Title        string `json:"title"`
+	LastModified string `json:"last-modified"`
+	Version      string `json:"version"`
+	OSCALVersion string `json:"oscal-version"`
+}
+
+// ImportAP references the assessment plan of the results.
+type ImportAP struct {
+	Href string `json:"href"`
+}
+
+type Result struct {
+	UUID             string            `json:"uuid"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	Start            string            `json:"start"`
+	End              string            `json:"end,omitempty"`
+	LocalDefinitions *LocalDefinitions `json:"local-definitions,omitempty"`
+	ReviewedControls ReviewedControls  `json:"reviewed-controls"`
+	Observations     []Observation     `json:"observations,omitempty"`
+	Findings         []Finding         `json:"findings,omitempty"`
+}
+
+type LocalDefinitions struct {
+	Components []SystemComponent `json:"components,omitempty"`
+}
+
+type SystemComponent struct {
+	UUID        string          `json:"uuid"`
+	Type        string          `json:"type"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Props       []Property      `json:"props,omitempty"`
+	Status      ComponentStatus `json:"status"`
+}
+
+type ComponentStatus struct {
+	State string `json:"state"`
+}
+
+type Property struct {
+	Name  string `json:"name"`
+	NS    string `json:"ns,omitempty"`
+	Value string `json:"value"`
+}
+
+type ReviewedControls struct {
+	ControlSelections []ControlSelection `json:"control-selections"`
+}
+
+// ControlSelection selects either all controls, or those of IncludeControls.
+type ControlSelection struct {
+	IncludeAll      *struct{}         `json:"include-all,omitempty"`
+	IncludeControls []SelectControlID `json:"include-controls,omitempty"`
+}
+
+type SelectControlID struct {
+	ControlID string `json:"control-id"`
+}
+
+type Observation struct {
+	UUID             string             `json:"uuid"`
+	Title            string             `json:"title,omitempty"`
+	Description      string             `json:"description"`
+	Props            []Property         `json:"props,omitempty"`
+	Methods          []string           `json:"methods"`
+	Subjects         []SubjectReference `json:"subjects,omitempty"`
+	RelevantEvidence []RelevantEvidence `json:"relevant-evidence,omitempty"`
+	Collected        string             `json:"collected"`
+}
+
+type SubjectReference struct {
+	SubjectUUID string `json:"subject-uuid"`
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+}
+
+type RelevantEvidence struct {
+	Description string `json:"description"`
+	Remarks     string `json:"remarks,omitempty"`
+}
+
+type Finding struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Props               []Property           `json:"props,omitempty"`
+	Target              FindingTarget        `json:"target"`
+	RelatedObservations []RelatedObservation `json:"related-observations,omitempty"`
+}
+
+type FindingTarget struct {
+	Type     string          `json:"type"`
+	TargetID string          `json:"target-id"`
+	Status   ObjectiveStatus `json:"status"`
+}
+
+type ObjectiveStatus struct {
+	State   string `json:"state"`
+	Reason  string `json:"reason,omitempty"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+type RelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+// Export builds the assessment results of the cluster. Every Component becomes a component, every
+// ComponentAttestation that ran an observation of its Component, and every Control applying to some
+// Component a finding, satisfied when all of its ComponentControls are implemented or waived.
+// argus has no assessment plans, so import-ap points to the document itself.
+func Export(ctx context.Context, cl client.Reader, now time.Time) (*AssessmentResultsDocument, error) {
+	ComponentList := argusiov1alpha1.ComponentList{}
+	err := cl.List(ctx, &ComponentList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Components: %w", err)
+	}
+	ControlList := argusiov1alpha1.ControlList{}
+	err = cl.List(ctx, &ControlList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Controls: %w", err)
+	}
+	ComponentControlList := argusiov1alpha1.ComponentControlList{}
+	err = cl.List(ctx, &ComponentControlList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list ComponentControls: %w", err)
+	}
+	ComponentAttestationList := argusiov1alpha1.ComponentAttestationList{}
+	err = cl.List(ctx, &ComponentAttestationList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list ComponentAttestations: %w", err)
+	}
+	ProviderList := argusiov1alpha1.AttestationProviderList{}
+	err = cl.List(ctx, &ProviderList)
+	if err != nil {
+		return nil, fmt.Errorf("could not list AttestationProviders: %w", err)
+	}
+	sort.Slice(ComponentList.Items, func(i, j int) bool {
+		return key(&ComponentList.Items[i]) < key(&ComponentList.Items[j])
+	})
+	sort.Slice(ComponentAttestationList.Items, func(i, j int) bool {
+		return key(&ComponentAttestationList.Items[i]) < key(&ComponentAttestationList.Items[j])
+	})
+	components := []SystemComponent{}
+	subjects := map[string]SubjectReference{}
+	for _, Component := range ComponentList.Items {
+		res := component(Component)
+		components = append(components, res)
+		subject := SubjectReference{SubjectUUID: res.UUID, Type: "component", Title: Component.Name}
+		// ComponentAttestations reference Components by name only, so the first one of a name wins.
+		if _, ok := subjects[Component.Name]; !ok {
+			subjects[Component.Name] = subject
+		}
+		subjects[key(&Component)] = subject
+	}
+	providers := map[string]argusiov1alpha1.AttestationProvider{}
+	for _, provider := range ProviderList.Items {
+		providers[key(&provider)] = provider
+	}
+	observations := []Observation{}
+	related := map[string][]RelatedObservation{}
+	var start, end time.Time
+	for _, ComponentAttestation := range ComponentAttestationList.Items {
+		runAt := ComponentAttestation.Status.Result.RunAt.Time
+		if runAt.IsZero() {
+			continue
+		}
+		if start.IsZero() || runAt.Before(start) {
+			start = runAt
+		}
+		if runAt.After(end) {
+			end = runAt
+		}
+		ComponentName := ComponentAttestation.Labels["argus.io/Component"]
+		subject, ok := subjects[ComponentAttestation.Namespace+"/"+ComponentName]
+		if !ok {
+			subject, ok = subjects[ComponentName]
+		}
+		ref := ComponentAttestation.Spec.ProviderRef
+		obs := observation(ComponentAttestation, providers[ref.Namespace+"/"+ref.Name])
+		if ok {
+			obs.Subjects = []SubjectReference{subject}
+		}
+		observations = append(observations, obs)
+		control := ComponentAttestation.Labels["argus.io/Control"]
+		related[control] = append(related[control], RelatedObservation{ObservationUUID: obs.UUID})
+	}
+	findings := findings(ControlList.Items, ComponentControlList.Items, related)
+	result := Result{
+		UUID:             UUID("result/" + timestamp(now)),
+		Title:            "argus attestation results",
+		Description:      fmt.Sprintf("Attestation results of %v Components against %v Controls.", len(components), len(findings)),
+		Start:            timestamp(now),
+		ReviewedControls: ReviewedControls{ControlSelections: []ControlSelection{{IncludeAll: &struct{}{}}}},
+	}
+	if !start.IsZero() {
+		result.Start = timestamp(start)
+		result.End = timestamp(end)
+	}
+	if len(components) > 0 {
+		result.LocalDefinitions = &LocalDefinitions{Components: components}
+	}
+	if len(observations) > 0 {
+		result.Observations = observations
+	}
+	if len(findings) > 0 {
+		result.Findings = findings
+		selection := ControlSelection{}
+		for _, finding := range findings {
+			selection.IncludeControls = append(selection.IncludeControls, SelectControlID{ControlID: finding.Target.TargetID})
+		}
+		result.ReviewedControls.ControlSelections = []ControlSelection{selection}
+	}
+	return &AssessmentResultsDocument{
+		AssessmentResults: AssessmentResults{
+			UUID: UUID("assessment-results/" + timestamp(now)),
+			Metadata: Metadata{
+				Title:        "argus assessment results",
+				LastModified: timestamp(now),
+				Version:      timestamp(now),
+				OSCALVersion: Version,
+			},
+			ImportAP: ImportAP{Href: "#"},
+			Results:  []Result{result},
+		},
+	}, nil
+}
+
+// Handler serves the assessment results of the cluster as JSON.
+func Handler(cl client.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, err := Export(r.Context(), cl, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(doc)
+	})
+}
+
+func component(res argusiov1alpha1.Component) SystemComponent {
+	componentType := strings.Join(strings.Fields(res.Spec.Type), " ")
+	if componentType == "" {
+		componentType = "other"
+	}
+	component := SystemComponent{
+		UUID:        UUID("component/" + key(&res)),
+		Type:        componentType,
+		Title:       res.Name,
+		Description: fmt.Sprintf("argus Component '%v' in namespace '%v'", res.Name, res.Namespace),
+		Status:      ComponentStatus{State: "operational"},
+	}
+	for _, class := range res.Spec.Classes {
+		component.Props = append(component.Props, Prop("class", class))
+	}
+	for _, parent := range res.Spec.Parents {
+		component.Props = append(component.Props, Prop("parent", parent))
+	}
+	return component
+}
+
+func observation(res argusiov1alpha1.ComponentAttestation, provider argusiov1alpha1.AttestationProvider) Observation {
+	result := res.Status.Result
+	description := fmt.Sprintf("Attestation '%v' of Control '%v' on Component '%v': %v",
+		res.Labels["argus.io/attestation"], res.Labels["argus.io/Control"], res.Labels["argus.io/Component"], result.Result)
+	if result.Reason != "" {
+		description = fmt.Sprintf("%v (%v)", description, result.Reason)
+	}
+	ref := res.Spec.ProviderRef
+	evidence := RelevantEvidence{
+		Description: fmt.Sprintf("Ran %v provider '%v/%v'", provider.Spec.Type, ref.Namespace, ref.Name),
+		Remarks:     result.Logs,
+	}
+	if len(provider.Spec.ProviderConfig) > 0 {
+		config := []string{}
+		for _, k := range utils.SortedKeys(provider.Spec.ProviderConfig) {
+			config = append(config, fmt.Sprintf("%v=%v", k, provider.Spec.ProviderConfig[k]))
+		}
+		evidence.Description = fmt.Sprintf("%v with %v", evidence.Description, strings.Join(config, " "))
+	}
+	return Observation{
+		UUID:        UUID(fmt.Sprintf("observation/%v/%v", key(&res), timestamp(result.RunAt.Time))),
+		Title:       res.Name,
+		Description: description,
+		Props: []Property{
+			Prop("result", string(result.Result)),
+			Prop("provider-type", provider.Spec.Type),
+		},
+		Methods:          []string{"TEST"},
+		RelevantEvidence: []RelevantEvidence{evidence},
+		Collected:        timestamp(result.RunAt.Time),
+	}
+}
+
+// findings returns a finding for every Control with ComponentControls, sorted by code and version.
+func findings(Controls []argusiov1alpha1.Control, ComponentControls []argusiov1alpha1.ComponentControl, related map[string][]RelatedObservation) []Finding {
+	total := map[string]int{}
+	compliant := map[string]int{}
+	for _, ComponentControl := range ComponentControls {
+		control := ComponentControl.Labels["argus.io/Control"]
+		total[control] = total[control] + 1
+		if componentcontrol.Implemented(ComponentControl) || ComponentControl.Status.Waived {
+			compliant[control] = compliant[control] + 1
+		}
+	}
+	sort.Slice(Controls, func(i, j int) bool {
+		return controlLabel(Controls[i]) < controlLabel(Controls[j])
+	})
+	res := []Finding{}
+	seen := map[string]bool{}
+	for _, Control := range Controls {
+		control := controlLabel(Control)
+		if total[control] == 0 || seen[control] {
+			continue
+		}
+		seen[control] = true
+		definition := Control.Spec.Definition
+		description := definition.Description
+		if description == "" {
+			description = definition.Code
+		}
+		status := ObjectiveStatus{
+			State:   "satisfied",
+			Reason:  "pass",
+			Remarks: fmt.Sprintf("%v/%v Components implement the Control, or are waived from it", compliant[control], total[control]),
+		}
+		if compliant[control] < total[control] {
+			status.State = "not-satisfied"
+			status.Reason = "fail"
+		}
+		res = append(res, Finding{
+			UUID:        UUID("finding/" + control),
+			Title:       definition.Code,
+			Description: description,
+			Props: []Property{
+				Prop("control-code", definition.Code),
+				Prop("control-version", definition.Version),
+			},
+			Target: FindingTarget{
+				Type:     "objective-id",
+				TargetID: Token(definition.Code),
+				Status:   status,
+			},
+			RelatedObservations: related[control],
+		})
+	}
+	return res
+}
+
+func controlLabel(res argusiov1alpha1.Control) string {
+	return fmt.Sprintf("%v_%v", res.Spec.Definition.Code, res.Spec.Definition.Version)
+}
+
+func key(obj client.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// uuidNamespace is the namespace of the name based UUIDs of argus.
+var uuidNamespace = [16]byte{0x6b, 0x3f, 0x1c, 0x52, 0x8e, 0x0d, 0x4a, 0x7b, 0x9c, 0x21, 0x5e, 0x43, 0xd6, 0x0a, 0x8f, 0x17}
+
+// UUID returns the version 5 UUID of a name, so that the same object always gets the same UUID.
+func UUID(name string) string {
+	h := sha1.New()
+	h.Write(uuidNamespace[:])
+	h.Write([]byte(name))
+	u := h.Sum(nil)[:16]
+	u[6] = (u[6] & 0x0f) | 0x50
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+var invalidToken = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+var tokenStart = regexp.MustCompile(`^[\p{L}_]`)
+
+// Token turns a code into an OSCAL token, as control ids must be.
+// Characters tokens cannot hold become '_', and tokens not starting with a letter get a '_' prefix.
+func Token(code string) string {
+	token := invalidToken.ReplaceAllString(code, "_")
+	if token == "" || !tokenStart.MatchString(token) {
+		token = "_" + token
+	}
+	return token
+}
+
+// Prop returns an argus property. OSCAL property values cannot span several lines nor be blank,
+// so whitespace is collapsed and blank values become "none".
+func Prop(name, value string) Property {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		value = "none"
+	}
+	return Property{Name: name, Value: value, NS: Namespace}
+}
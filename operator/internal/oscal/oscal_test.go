@@ -0,0 +1,237 @@
+package oscal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// testdata/oscal_assessment-results_schema.json is the assessment-results part of the OSCAL 1.1.2 complete JSON schema.
+const schemaPath = "testdata/oscal_assessment-results_schema.json"
+
+var now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.NoError(t, err)
+	runAt := now.Add(-time.Hour)
+	cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(
+		makeComponent("vm"),
+		makeComponent("db"),
+		makeControl("CTRL-01", "1"),
+		makeControl("1.2.1", "1.8"),
+		makeControl("CTRL-09", "1"),
+		makeComponentControl("vm", "CTRL-01_1", 1, 1, false),
+		makeComponentControl("db", "CTRL-01_1", 1, 0, false),
+		makeComponentControl("db", "1.2.1_1.8", 1, 0, true),
+		makeProvider(),
+		makeComponentAttestation("vm", "CTRL-01_1", argusiov1alpha1.AttestationResultTypePass, runAt),
+		makeComponentAttestation("db", "CTRL-01_1", argusiov1alpha1.AttestationResultTypeFail, runAt.Add(time.Minute)),
+		makeComponentAttestation("db", "1.2.1_1.8", argusiov1alpha1.AttestationResultTypeNotStarted, time.Time{}),
+	).Build()
+	doc, err := Export(context.Background(), cl, now)
+	require.NoError(t, err)
+	validate(t, doc)
+
+	result := doc.AssessmentResults.Results[0]
+	assert.Equal(t, "2023-06-01T11:00:00Z", result.Start)
+	assert.Equal(t, "2023-06-01T11:01:00Z", result.End)
+	require.Len(t, result.LocalDefinitions.Components, 2)
+	db, vm := result.LocalDefinitions.Components[0], result.LocalDefinitions.Components[1]
+	assert.Equal(t, "db", db.Title)
+	assert.Equal(t, []Property{{Name: "class", NS: Namespace, Value: "VirtualMachine"}}, vm.Props)
+
+	// ComponentAttestations that never ran are no observation.
+	require.Len(t, result.Observations, 2)
+	fail, pass := result.Observations[0], result.Observations[1]
+	assert.Equal(t, []SubjectReference{{SubjectUUID: db.UUID, Type: "component", Title: "db"}}, fail.Subjects)
+	assert.Equal(t, []SubjectReference{{SubjectUUID: vm.UUID, Type: "component", Title: "vm"}}, pass.Subjects)
+	assert.Equal(t, []RelevantEvidence{{Description: "Ran command provider 'default/checks' with cmd=/bin/check.sh", Remarks: "$ /bin/check.sh\n"}}, pass.RelevantEvidence)
+	assert.Equal(t, "2023-06-01T11:01:00Z", fail.Collected)
+
+	// Controls without ComponentControls are no finding.
+	require.Len(t, result.Findings, 2)
+	cis, ctrl := result.Findings[0], result.Findings[1]
+	assert.Equal(t, "_1.2.1", cis.Target.TargetID)
+	assert.Equal(t, "satisfied", cis.Target.Status.State)
+	assert.Equal(t, "CTRL-01", ctrl.Target.TargetID)
+	assert.Equal(t, "not-satisfied", ctrl.Target.Status.State)
+	assert.Equal(t, "1/2 Components implement the Control, or are waived from it", ctrl.Target.Status.Remarks)
+	assert.ElementsMatch(t, []RelatedObservation{{ObservationUUID: pass.UUID}, {ObservationUUID: fail.UUID}}, ctrl.RelatedObservations)
+	assert.Equal(t, []SelectControlID{{ControlID: "_1.2.1"}, {ControlID: "CTRL-01"}}, result.ReviewedControls.ControlSelections[0].IncludeControls)
+}
+
+func TestExportEmpty(t *testing.T) {
+	t.Parallel()
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.NoError(t, err)
+	doc, err := Export(context.Background(), fake.NewClientBuilder().WithScheme(commonScheme).Build(), now)
+	require.NoError(t, err)
+	validate(t, doc)
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	commonScheme := runtime.NewScheme()
+	err := argusiov1alpha1.AddToScheme(commonScheme)
+	require.NoError(t, err)
+	cl := fake.NewClientBuilder().WithScheme(commonScheme).WithObjects(makeComponent("vm")).Build()
+	rec := httptest.NewRecorder()
+	Handler(cl).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	doc := AssessmentResultsDocument{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "vm", doc.AssessmentResults.Results[0].LocalDefinitions.Components[0].Title)
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "no token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid token",
+			token:        "invalid",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "user not allowed to get the path",
+			token:        "developer",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "user allowed to get the path",
+			token:        "auditor",
+			expectedCode: http.StatusOK,
+		},
+	}
+	// Tokens are the names of their users, and only auditors may get the assessment results.
+	cl := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				review.Status.Authenticated = review.Spec.Token != "invalid"
+				review.Status.User.Username = review.Spec.Token
+			case *authorizationv1.SubjectAccessReview:
+				review.Status.Allowed = review.Spec.User == "auditor" && review.Spec.NonResourceAttributes.Path == Path
+			}
+			return nil
+		},
+	}).Build()
+	handler := Authorize(cl, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, Path, nil)
+			if testCase.token != "" {
+				req.Header.Set("Authorization", "Bearer "+testCase.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, testCase.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "CTRL-01", Token("CTRL-01"))
+	assert.Equal(t, "_1.2.1", Token("1.2.1"))
+	assert.Equal(t, "SEC_POL_01", Token("SEC POL/01"))
+}
+
+// Helpers
+
+func validate(t *testing.T, doc *AssessmentResultsDocument) {
+	t.Helper()
+	sch, err := jsonschema.Compile(schemaPath)
+	require.NoError(t, err)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var v interface{}
+	require.NoError(t, json.Unmarshal(data, &v))
+	require.NoError(t, sch.Validate(v))
+}
+
+func makeComponent(name string) client.Object {
+	return &argusiov1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       argusiov1alpha1.ComponentSpec{Type: "VirtualMachine", Classes: []string{"VirtualMachine"}},
+	}
+}
+
+func makeControl(code, version string) client.Object {
+	return &argusiov1alpha1.Control{
+		ObjectMeta: metav1.ObjectMeta{Name: Token(code + "-" + version), Namespace: "default"},
+		Spec: argusiov1alpha1.ControlSpec{
+			Definition: argusiov1alpha1.ControlDefinition{Code: code, Version: version, Description: "A control."},
+		},
+	}
+}
+
+func makeComponentControl(component, control string, total, valid int, waived bool) client.Object {
+	return &argusiov1alpha1.ComponentControl{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component + "-" + control,
+			Namespace: "default",
+			Labels:    map[string]string{"argus.io/Component": component, "argus.io/Control": control},
+		},
+		Status: argusiov1alpha1.ComponentControlStatus{TotalAssessments: total, ValidAssessments: valid, Waived: waived},
+	}
+}
+
+func makeProvider() client.Object {
+	return &argusiov1alpha1.AttestationProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "checks", Namespace: "default"},
+		Spec: argusiov1alpha1.AttestationProviderSpec{
+			Type:           "command",
+			ProviderConfig: map[string]string{"cmd": "/bin/check.sh"},
+		},
+	}
+}
+
+func makeComponentAttestation(component, control string, result argusiov1alpha1.AttestationResultType, runAt time.Time) client.Object {
+	return &argusiov1alpha1.ComponentAttestation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component + "-" + control,
+			Namespace: "default",
+			Labels: map[string]string{
+				"argus.io/attestation": "check",
+				"argus.io/Component":   component,
+				"argus.io/Control":     control,
+			},
+		},
+		Spec: argusiov1alpha1.ComponentAttestationSpec{
+			ProviderRef: argusiov1alpha1.AttestationProviderRef{Name: "checks", Namespace: "default"},
+		},
+		Status: argusiov1alpha1.ComponentAttestationStatus{
+			Result: argusiov1alpha1.AttestationResult{Result: result, Logs: "$ /bin/check.sh\n", RunAt: metav1.NewTime(runAt)},
+		},
+	}
+}
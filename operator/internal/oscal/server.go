@@ -0,0 +1,101 @@
+package oscal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Path is the path the assessment results are served at.
+const Path = "/oscal/assessment-results"
+
+// Server serves the assessment results over TLS on a listener of its own, apart from the metrics,
+// as they hold the commands and logs of every attestation. It is added to the manager as a Runnable.
+type Server struct {
+	// Addr is the address the server binds to.
+	Addr string
+	// CertDir holds the tls.crt and tls.key the server serves.
+	CertDir string
+	// Client reads the objects to export, and reviews the tokens of the requests.
+	Client client.Client
+}
+
+// Start serves the assessment results until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Authorize(s.Client, Handler(s.Client)))
+	srv := &http.Server{Addr: s.Addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServeTLS(filepath.Join(s.CertDir, "tls.crt"), filepath.Join(s.CertDir, "tls.key"))
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		err := srv.Shutdown(context.Background())
+		if err != nil {
+			return err
+		}
+		if err = <-errs; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// NeedLeaderElection is false, so that every replica serves the assessment results.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Authorize only lets through requests bearing a token of a user the Kubernetes API authorizes
+// to get the requested path, as kube-rbac-proxy does for the metrics.
+func Authorize(cl client.Client, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+		err := cl.Create(r.Context(), review)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !review.Status.Authenticated {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		user := review.Status.User
+		extra := map[string]authorizationv1.ExtraValue{}
+		for k, v := range user.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+		access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:                  user.Username,
+			UID:                   user.UID,
+			Groups:                user.Groups,
+			Extra:                 extra,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: r.URL.Path, Verb: "get"},
+		}}
+		err = cl.Create(r.Context(), access)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !access.Status.Allowed {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
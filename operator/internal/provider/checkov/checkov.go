@@ -1,11 +1,13 @@
 package checkov
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"os/exec"
 	"sync"
+	"time"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	provider "github.com/ContainerSolutions/argus/operator/internal/provider/schema"
@@ -14,22 +16,28 @@ import (
 
 var mu = sync.Mutex{}
 
+// waitDelay bounds how long we wait for output pipes to close after a command was killed.
+const waitDelay = 5 * time.Second
+
 type Client struct {
 	RepoUrl string
 	Checks  string
 	Result  argusiov1alpha1.AttestationResultType
 }
 
-func (c *Client) Attest() (argusiov1alpha1.AttestationResult, error) {
+func (c *Client) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
 	mu.Lock()
 	defer func() {
 		os.RemoveAll("/tmp/location")
 		mu.Unlock()
 	}()
 	clone_location := "/tmp/location"
-	cmd := exec.Command("git", "clone", c.RepoUrl, clone_location)
+	cmd := exec.CommandContext(ctx, "git", "clone", c.RepoUrl, clone_location)
+	cmd.WaitDelay = waitDelay
 	out, err := cmd.CombinedOutput()
-
+	if provider.TimedOut(ctx) {
+		return provider.NewTimeoutResult(ctx, string(out)), nil
+	}
 	if err != nil {
 		res := argusiov1alpha1.AttestationResult{
 			Result: argusiov1alpha1.AttestationResultTypeUnknown,
@@ -41,9 +49,12 @@ func (c *Client) Attest() (argusiov1alpha1.AttestationResult, error) {
 		return res, err
 	}
 
-	checkov_cmd := exec.Command("checkov", "-d", clone_location, "--check", c.Checks, "-o", "cli")
-
+	checkov_cmd := exec.CommandContext(ctx, "checkov", "-d", clone_location, "--check", c.Checks, "-o", "cli")
+	checkov_cmd.WaitDelay = waitDelay
 	out, err = checkov_cmd.CombinedOutput()
+	if provider.TimedOut(ctx) {
+		return provider.NewTimeoutResult(ctx, string(out)), nil
+	}
 
 	res := argusiov1alpha1.AttestationResult{
 		Result: argusiov1alpha1.AttestationResultTypePass,
@@ -87,6 +98,13 @@ func (p *Provider) New(name string, spec *argusiov1alpha1.AttestationProviderSpe
 	return c, nil
 }
 
+func (p *Provider) ConfigSchema() []provider.ConfigField {
+	return []provider.ConfigField{
+		{Name: "repo", Description: "URL of the git repository to scan.", Required: true},
+		{Name: "checks", Description: "Comma separated list of checkov checks to run."},
+	}
+}
+
 func init() {
 	provider.Register(&Provider{}, "checkov")
 }
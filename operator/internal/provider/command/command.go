@@ -1,24 +1,33 @@
 package command
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"strconv"
+	"time"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	provider "github.com/ContainerSolutions/argus/operator/internal/provider/schema"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// waitDelay bounds how long we wait for output pipes to close after the command was killed.
+const waitDelay = 5 * time.Second
+
 type Client struct {
 	Command            string
 	ExpectedStatusCode int
 }
 
-func (c *Client) Attest() (argusiov1alpha1.AttestationResult, error) {
-	cmd := exec.Command(c.Command)
+func (c *Client) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
+	cmd := exec.CommandContext(ctx, c.Command)
 	cmd.Env = os.Environ()
+	cmd.WaitDelay = waitDelay
 	out, err := cmd.CombinedOutput()
+	if provider.TimedOut(ctx) {
+		return provider.NewTimeoutResult(ctx, string(out)), nil
+	}
 	result := argusiov1alpha1.AttestationResultTypePass
 	if cmd.ProcessState.ExitCode() != c.ExpectedStatusCode {
 		result = argusiov1alpha1.AttestationResultTypeFail
@@ -55,6 +64,13 @@ func (p *Provider) New(name string, spec *argusiov1alpha1.AttestationProviderSpe
 	return c, nil
 }
 
+func (p *Provider) ConfigSchema() []provider.ConfigField {
+	return []provider.ConfigField{
+		{Name: "cmd", Description: "Path of the command to run.", Required: true},
+		{Name: "expectedStatusCode", Description: "Exit code of a passing run. Defaults to 0."},
+	}
+}
+
 func init() {
 	provider.Register(&Provider{}, "command")
 }
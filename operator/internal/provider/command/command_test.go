@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttest(t *testing.T) {
+	testCases := []struct {
+		name           string
+		script         string
+		timeout        time.Duration
+		expectedResult argusiov1alpha1.AttestationResultType
+		expectedReason string
+	}{
+		{
+			name:           "pass",
+			script:         "#!/bin/sh\nexit 0\n",
+			timeout:        10 * time.Second,
+			expectedResult: argusiov1alpha1.AttestationResultTypePass,
+			expectedReason: "command execution output",
+		},
+		{
+			name:           "fail",
+			script:         "#!/bin/sh\nexit 1\n",
+			timeout:        10 * time.Second,
+			expectedResult: argusiov1alpha1.AttestationResultTypeFail,
+			expectedReason: "command execution output",
+		},
+		{
+			name:           "timeout kills the command",
+			script:         "#!/bin/sh\nexec sleep 30\n",
+			timeout:        100 * time.Millisecond,
+			expectedResult: argusiov1alpha1.AttestationResultTypeUnknown,
+			expectedReason: "attestation timed out",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "attest.sh")
+			require.NoError(t, os.WriteFile(path, []byte(testCase.script), 0o700))
+			c := &Client{Command: path}
+			ctx, cancel := context.WithTimeout(context.Background(), testCase.timeout)
+			defer cancel()
+			start := time.Now()
+			res, err := c.Attest(ctx)
+			require.NoError(t, err)
+			assert.Less(t, time.Since(start), 10*time.Second)
+			assert.Equal(t, testCase.expectedResult, res.Result)
+			assert.Equal(t, testCase.expectedReason, res.Reason)
+		})
+	}
+}
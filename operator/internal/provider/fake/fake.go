@@ -1,7 +1,9 @@
 package fake
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
 	provider "github.com/ContainerSolutions/argus/operator/internal/provider/schema"
@@ -10,9 +12,18 @@ import (
 
 type Client struct {
 	Result argusiov1alpha1.AttestationResultType
+	Delay  time.Duration
 }
 
-func (c *Client) Attest() (argusiov1alpha1.AttestationResult, error) {
+func (c *Client) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
+	select {
+	case <-ctx.Done():
+		if provider.TimedOut(ctx) {
+			return provider.NewTimeoutResult(ctx, "fake"), nil
+		}
+		return argusiov1alpha1.AttestationResult{}, ctx.Err()
+	case <-time.After(c.Delay):
+	}
 	res := argusiov1alpha1.AttestationResult{
 		Result: c.Result,
 		Logs:   "fake",
@@ -30,9 +41,17 @@ type Provider struct{}
 
 func (p *Provider) New(name string, spec *argusiov1alpha1.AttestationProviderSpec) (provider.AttestationClient, error) {
 	var res argusiov1alpha1.AttestationResultType
+	var delay time.Duration
+	if d, ok := spec.ProviderConfig["delay"]; ok {
+		var err error
+		delay, err = time.ParseDuration(d)
+		if err != nil {
+			return nil, fmt.Errorf("expected duration in 'delay': %w", err)
+		}
+	}
 	r, ok := spec.ProviderConfig["result"]
 	if !ok {
-		return &Client{Result: argusiov1alpha1.AttestationResultTypePass}, nil
+		return &Client{Result: argusiov1alpha1.AttestationResultTypePass, Delay: delay}, nil
 	}
 	switch r {
 	case "Pass":
@@ -44,10 +63,18 @@ func (p *Provider) New(name string, spec *argusiov1alpha1.AttestationProviderSpe
 	}
 	c := &Client{
 		Result: res,
+		Delay:  delay,
 	}
 	return c, nil
 }
 
+func (p *Provider) ConfigSchema() []provider.ConfigField {
+	return []provider.ConfigField{
+		{Name: "result", Description: "Result to report: Pass, Fail or anything else for Unknown. Defaults to Pass."},
+		{Name: "delay", Description: "How long each attestation takes, as a duration."},
+	}
+}
+
 func init() {
 	provider.Register(&Provider{}, "fake")
 }
@@ -6,6 +6,7 @@ package file
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -54,13 +55,24 @@ func newPassResult(reason string) argusiov1alpha1.AttestationResult {
 	}
 	return r
 }
-func (c *Client) Attest() (argusiov1alpha1.AttestationResult, error) {
+func (c *Client) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
 	p := http.Client{}
-	resp, err := p.Get(c.url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return newUnknownResult(fmt.Sprintf("could not build request for url '%v'", c.url), err), err
+	}
+	resp, err := p.Do(req)
+	if provider.TimedOut(ctx) {
+		return provider.NewTimeoutResult(ctx, ""), nil
+	}
 	if err != nil {
 		return newUnknownResult(fmt.Sprintf("could not GET url '%v'", c.url), err), err
 	}
+	defer resp.Body.Close()
 	resBody, err := io.ReadAll(resp.Body)
+	if provider.TimedOut(ctx) {
+		return provider.NewTimeoutResult(ctx, ""), nil
+	}
 	if err != nil {
 		return newUnknownResult(fmt.Sprintf("could read response body for url '%v'", c.url), err), err
 	}
@@ -136,6 +148,16 @@ func (p *Provider) New(name string, spec *argusiov1alpha1.AttestationProviderSpe
 	return c, nil
 }
 
+func (p *Provider) ConfigSchema() []provider.ConfigField {
+	return []provider.ConfigField{
+		{Name: "url", Description: "URL of the page to match.", Required: true},
+		{Name: "positiveRegexp", Description: "Expression the page must match. One of positiveRegexp or negativeRegexp is required."},
+		{Name: "negativeRegexp", Description: "Expression the page must not match."},
+		{Name: "minPositiveMatches", Description: "Minimum number of positive matches. Defaults to 1."},
+		{Name: "maxNegativeMatches", Description: "Maximum number of negative matches. Defaults to 0."},
+	}
+}
+
 func init() {
 	provider.Register(&Provider{}, "file")
 }
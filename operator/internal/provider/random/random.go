@@ -1,6 +1,7 @@
 package random
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -21,7 +22,10 @@ type Client struct {
 	current argusiov1alpha1.AttestationResultType
 }
 
-func (c *Client) Attest() (argusiov1alpha1.AttestationResult, error) {
+func (c *Client) Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error) {
+	if provider.TimedOut(ctx) {
+		return provider.NewTimeoutResult(ctx, "random"), nil
+	}
 	a := time.Since(c.last)
 	if a > c.reroll {
 		c.last = time.Now()
@@ -70,6 +74,12 @@ func (p *Provider) New(name string, spec *argusiov1alpha1.AttestationProviderSpe
 	return client, nil
 }
 
+func (p *Provider) ConfigSchema() []provider.ConfigField {
+	return []provider.ConfigField{
+		{Name: "regenerate", Description: "How often a new random result is drawn, as a duration. Defaults to 15m."},
+	}
+}
+
 func init() {
 	provider.Register(&Provider{}, "random")
 }
@@ -1,10 +1,15 @@
 package schema
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var Builder map[string]Provider
@@ -12,10 +17,19 @@ var buildlock sync.RWMutex
 
 type Provider interface {
 	New(name string, spec *argusiov1alpha1.AttestationProviderSpec) (AttestationClient, error)
+	// ConfigSchema lists the providerConfig keys the provider understands.
+	ConfigSchema() []ConfigField
+}
+
+// ConfigField describes a key of the providerConfig of an AttestationProvider.
+type ConfigField struct {
+	Name        string
+	Description string
+	Required    bool
 }
 
 type AttestationClient interface {
-	Attest() (argusiov1alpha1.AttestationResult, error)
+	Attest(ctx context.Context) (argusiov1alpha1.AttestationResult, error)
 	Close() error
 }
 
@@ -39,3 +53,46 @@ func ForceRegister(s Provider, providerName string) {
 	defer buildlock.Unlock()
 	Builder[providerName] = s
 }
+
+// TimedOut reports whether the attestation deadline passed.
+func TimedOut(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// NewTimeoutResult returns the Unknown result providers report when their deadline passes.
+func NewTimeoutResult(ctx context.Context, logs string) argusiov1alpha1.AttestationResult {
+	return argusiov1alpha1.AttestationResult{
+		Result: argusiov1alpha1.AttestationResultTypeUnknown,
+		Logs:   logs,
+		RunAt:  v1.Now(),
+		Reason: "attestation timed out",
+		Err:    ctx.Err().Error(),
+	}
+}
+
+// ValidateConfig checks a providerConfig against the schema of its provider.
+// Required keys must be set, and keys the schema does not list are rejected.
+func ValidateConfig(fields []ConfigField, config map[string]string) error {
+	known := map[string]bool{}
+	missing := []string{}
+	for _, f := range fields {
+		known[f.Name] = true
+		if _, ok := config[f.Name]; f.Required && !ok {
+			missing = append(missing, f.Name)
+		}
+	}
+	unknown := []string{}
+	for k := range config {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	switch {
+	case len(missing) > 0:
+		return fmt.Errorf("missing required providerConfig keys: %v", strings.Join(missing, ", "))
+	case len(unknown) > 0:
+		return fmt.Errorf("unknown providerConfig keys: %v", strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	fields := []ConfigField{
+		{Name: "url", Required: true},
+		{Name: "regexp"},
+	}
+	testCases := []struct {
+		name          string
+		config        map[string]string
+		expectedError string
+	}{
+		{
+			name:   "required only",
+			config: map[string]string{"url": "http://example.com"},
+		},
+		{
+			name:   "all keys",
+			config: map[string]string{"url": "http://example.com", "regexp": "ok"},
+		},
+		{
+			name:          "missing required key",
+			config:        map[string]string{"regexp": "ok"},
+			expectedError: "missing required providerConfig keys: url",
+		},
+		{
+			name:          "unknown keys",
+			config:        map[string]string{"url": "http://example.com", "regex": "ok", "Url": "x"},
+			expectedError: "unknown providerConfig keys: Url, regex",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateConfig(fields, testCase.config)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule returns the next time something should run, after a given time.
+type Schedule interface {
+	Next(time.Time) time.Time
+}
+
+type interval time.Duration
+
+func (i interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// Parse reads an interval such as '30s' or '6h', or a standard cron expression
+// such as '0 2 * * *', '@daily' or '@every 1h'. Cron expressions are evaluated in the
+// operator time zone, unless they start with 'CRON_TZ=<zone>'.
+func Parse(expr string) (Schedule, error) {
+	d, err := time.ParseDuration(expr)
+	if err == nil {
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid schedule %q: interval must be positive", expr)
+		}
+		return interval(d), nil
+	}
+	s, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+	return s, nil
+}
+
+// Due reports whether something last run at lastRun should run again at now.
+// Something that never ran is always due.
+func Due(s Schedule, lastRun, now time.Time) bool {
+	return lastRun.IsZero() || !now.Before(s.Next(lastRun))
+}
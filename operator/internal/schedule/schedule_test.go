@@ -0,0 +1,99 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	from := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		expr          string
+		expectedNext  time.Time
+		expectedError string
+	}{
+		{
+			name:         "interval",
+			expr:         "90s",
+			expectedNext: from.Add(90 * time.Second),
+		},
+		{
+			name:         "cron",
+			expr:         "CRON_TZ=UTC 0 2 * * *",
+			expectedNext: time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "descriptor",
+			expr:         "CRON_TZ=UTC @hourly",
+			expectedNext: time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "every",
+			expr:         "@every 5m",
+			expectedNext: from.Add(5 * time.Minute),
+		},
+		{
+			name:          "negative interval",
+			expr:          "-1m",
+			expectedError: "interval must be positive",
+		},
+		{
+			name:          "invalid",
+			expr:          "0 2 * *",
+			expectedError: "invalid schedule \"0 2 * *\"",
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(testCase.expr)
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedNext, s.Next(from).UTC())
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestDue(t *testing.T) {
+	s, err := Parse("1h")
+	require.NoError(t, err)
+	lastRun := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		lastRun  time.Time
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "never run",
+			now:      lastRun,
+			expected: true,
+		},
+		{
+			name:     "before next slot",
+			lastRun:  lastRun,
+			now:      lastRun.Add(59 * time.Minute),
+			expected: false,
+		},
+		{
+			name:     "at next slot",
+			lastRun:  lastRun,
+			now:      lastRun.Add(time.Hour),
+			expected: true,
+		},
+	}
+	for i := range testCases {
+		testCase := testCases[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Due(s, testCase.lastRun, testCase.now))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package utils
 
+import "sort"
+
 func Contains(arr []string, val string) bool {
 	for _, item := range arr {
 		if item == val {
@@ -17,3 +19,13 @@ func ContainsOne(target []string, arr []string) bool {
 	}
 	return false
 }
+
+// SortedKeys returns the keys of a map in order, so that maps are walked deterministically.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/assessment"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+// SetupAssessmentWebhookWithManager registers the Assessment webhooks in the manager.
+func SetupAssessmentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argusiov1alpha1.Assessment{}).
+		WithDefaulter(&AssessmentCustomDefaulter{}).
+		WithValidator(&AssessmentCustomValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-argus-io-v1alpha1-assessment,mutating=true,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=assessments,verbs=create;update,versions=v1alpha1,name=massessment.argus.io,admissionReviewVersions=v1
+
+// AssessmentCustomDefaulter sets the default cascadePolicy of Assessments.
+type AssessmentCustomDefaulter struct{}
+
+func (d *AssessmentCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	res, ok := obj.(*argusiov1alpha1.Assessment)
+	if !ok {
+		return fmt.Errorf("expected an Assessment but got %T", obj)
+	}
+	lib.Default(res)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-argus-io-v1alpha1-assessment,mutating=false,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=assessments,verbs=create;update,versions=v1alpha1,name=vassessment.argus.io,admissionReviewVersions=v1
+
+// AssessmentCustomValidator rejects Assessments whose controlRef matches no Control.
+// Controls are read from the API server rather than the cache, so that a Control created
+// right before the Assessment is found.
+type AssessmentCustomValidator struct {
+	Client client.Reader
+}
+
+func (v *AssessmentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	res, ok := obj.(*argusiov1alpha1.Assessment)
+	if !ok {
+		return nil, fmt.Errorf("expected an Assessment but got %T", obj)
+	}
+	var warnings admission.Warnings
+	if len(res.Spec.ComponentRef) == 0 && res.Spec.ComponentSelector == nil {
+		warnings = append(warnings, "neither componentRef nor componentSelector is set, the Assessment targets no Components")
+	}
+	return warnings, lib.Validate(ctx, v.Client, res)
+}
+
+func (v *AssessmentCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *AssessmentCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+var _ = Describe("Assessment Webhook", func() {
+	BeforeEach(func() {
+		err := k8sClient.Create(ctx, makeControl("assessment-ctrl", "ASM-01", "1.0.0"))
+		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
+	})
+
+	It("defaults cascadePolicy to Cascade", func() {
+		res := makeAssessment("defaulted", "ASM-01", "1.0.0")
+		Expect(k8sClient.Create(ctx, res)).To(Succeed())
+		created := argusiov1alpha1.Assessment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: res.Name, Namespace: namespace}, &created)).To(Succeed())
+		Expect(created.Spec.CascadePolicy).To(Equal(argusiov1alpha1.CascadingPolicyCascade))
+	})
+
+	It("keeps an explicit cascadePolicy", func() {
+		res := makeAssessment("explicit", "ASM-01", "1.0.0")
+		res.Spec.CascadePolicy = argusiov1alpha1.CascadingPolicyNone
+		Expect(k8sClient.Create(ctx, res)).To(Succeed())
+		created := argusiov1alpha1.Assessment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: res.Name, Namespace: namespace}, &created)).To(Succeed())
+		Expect(created.Spec.CascadePolicy).To(Equal(argusiov1alpha1.CascadingPolicyNone))
+	})
+
+	It("rejects a controlRef matching no Control", func() {
+		err := k8sClient.Create(ctx, makeAssessment("dangling", "ASM-01", "2.0.0"))
+		Expect(err).To(MatchError(ContainSubstring("no Control with code 'ASM-01' and version '2.0.0'")))
+	})
+
+	It("rejects an invalid componentSelector", func() {
+		res := makeAssessment("bad-selector", "ASM-01", "1.0.0")
+		res.Spec.ComponentSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+		}
+		err := k8sClient.Create(ctx, res)
+		Expect(err).To(MatchError(ContainSubstring("invalid componentSelector")))
+	})
+
+	It("rejects updates pointing the controlRef at no Control", func() {
+		res := makeAssessment("updated", "ASM-01", "1.0.0")
+		Expect(k8sClient.Create(ctx, res)).To(Succeed())
+		res.Spec.ControlRef.Code = "ASM-02"
+		err := k8sClient.Update(ctx, res)
+		Expect(err).To(MatchError(ContainSubstring("no Control with code 'ASM-02'")))
+	})
+})
+
+func makeAssessment(name, code, version string) *argusiov1alpha1.Assessment {
+	return &argusiov1alpha1.Assessment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: argusiov1alpha1.AssessmentSpec{
+			Class: "Preventative",
+			ControlRef: argusiov1alpha1.AssessmentControlDefinition{
+				Code:    code,
+				Version: version,
+			},
+			ComponentRef: []argusiov1alpha1.NamespacedName{{Name: "component", Namespace: namespace}},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/attestation"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+// SetupAttestationWebhookWithManager registers the Attestation webhook in the manager.
+func SetupAttestationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argusiov1alpha1.Attestation{}).
+		WithValidator(&AttestationCustomValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argus-io-v1alpha1-attestation,mutating=false,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=attestations,verbs=create;update,versions=v1alpha1,name=vattestation.argus.io,admissionReviewVersions=v1
+
+// AttestationCustomValidator rejects Attestations whose assessmentRef or providerRef do not exist.
+// References are read from the API server rather than the cache, so that objects created
+// right before the Attestation are found.
+type AttestationCustomValidator struct {
+	Client client.Reader
+}
+
+func (v *AttestationCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	res, ok := obj.(*argusiov1alpha1.Attestation)
+	if !ok {
+		return nil, fmt.Errorf("expected an Attestation but got %T", obj)
+	}
+	return nil, lib.Validate(ctx, v.Client, res)
+}
+
+func (v *AttestationCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *AttestationCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
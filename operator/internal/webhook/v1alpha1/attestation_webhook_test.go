@@ -0,0 +1,68 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+var _ = Describe("Attestation Webhook", func() {
+	BeforeEach(func() {
+		err := k8sClient.Create(ctx, makeControl("attestation-ctrl", "ATT-01", "1.0.0"))
+		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
+		err = k8sClient.Create(ctx, makeAssessment("attestation-assessment", "ATT-01", "1.0.0"))
+		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
+		err = k8sClient.Create(ctx, makeAttestationProvider("attestation-prov", "fake", map[string]string{}))
+		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
+	})
+
+	It("accepts an Attestation with existing references", func() {
+		Expect(k8sClient.Create(ctx, makeAttestation("valid-attestation", "attestation-assessment", "attestation-prov"))).To(Succeed())
+	})
+
+	It("rejects a dangling assessmentRef", func() {
+		err := k8sClient.Create(ctx, makeAttestation("no-assessment", "missing", "attestation-prov"))
+		Expect(err).To(MatchError(ContainSubstring("assessmentRef: Assessment 'missing' does not exist")))
+	})
+
+	It("rejects a providerRef to a missing AttestationProvider", func() {
+		err := k8sClient.Create(ctx, makeAttestation("no-provider", "attestation-assessment", "missing"))
+		Expect(err).To(MatchError(ContainSubstring("providerRef: AttestationProvider 'webhooks/missing' does not exist")))
+	})
+})
+
+func makeAttestation(name, assessment, provider string) *argusiov1alpha1.Attestation {
+	return &argusiov1alpha1.Attestation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: argusiov1alpha1.AttestationSpec{
+			AssessmentRef: assessment,
+			ProviderRef: argusiov1alpha1.AttestationProviderRef{
+				Name:      provider,
+				Namespace: namespace,
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/attestationprovider"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+// SetupAttestationProviderWebhookWithManager registers the AttestationProvider webhook in the manager.
+func SetupAttestationProviderWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argusiov1alpha1.AttestationProvider{}).
+		WithValidator(&AttestationProviderCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argus-io-v1alpha1-attestationprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=attestationproviders,verbs=create;update,versions=v1alpha1,name=vattestationprovider.argus.io,admissionReviewVersions=v1
+
+// AttestationProviderCustomValidator rejects AttestationProviders of unknown types
+// or whose providerConfig their type does not accept.
+type AttestationProviderCustomValidator struct{}
+
+func (v *AttestationProviderCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	res, ok := obj.(*argusiov1alpha1.AttestationProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected an AttestationProvider but got %T", obj)
+	}
+	return nil, lib.Validate(res)
+}
+
+func (v *AttestationProviderCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *AttestationProviderCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
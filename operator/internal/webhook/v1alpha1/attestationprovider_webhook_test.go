@@ -0,0 +1,55 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+var _ = Describe("AttestationProvider Webhook", func() {
+	It("accepts a valid AttestationProvider", func() {
+		Expect(k8sClient.Create(ctx, makeAttestationProvider("valid-prov", "command", map[string]string{"cmd": "/bin/true"}))).To(Succeed())
+	})
+
+	It("rejects an unknown type", func() {
+		err := k8sClient.Create(ctx, makeAttestationProvider("unknown-type", "shell", map[string]string{"cmd": "/bin/true"}))
+		Expect(err).To(MatchError(ContainSubstring("provider 'shell' doesn't exist")))
+	})
+
+	It("rejects a providerConfig missing required keys", func() {
+		err := k8sClient.Create(ctx, makeAttestationProvider("missing-url", "file", map[string]string{"positiveRegexp": "ok"}))
+		Expect(err).To(MatchError(ContainSubstring("missing required providerConfig keys: url")))
+	})
+})
+
+func makeAttestationProvider(name, providerType string, config map[string]string) *argusiov1alpha1.AttestationProvider {
+	return &argusiov1alpha1.AttestationProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: argusiov1alpha1.AttestationProviderSpec{
+			Type:           providerType,
+			ProviderConfig: config,
+		},
+	}
+}
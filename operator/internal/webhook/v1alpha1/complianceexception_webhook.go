@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/complianceexception"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+// SetupComplianceExceptionWebhookWithManager registers the ComplianceException webhook in the manager.
+func SetupComplianceExceptionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argusiov1alpha1.ComplianceException{}).
+		WithValidator(&ComplianceExceptionCustomValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argus-io-v1alpha1-complianceexception,mutating=false,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=complianceexceptions,verbs=create;update,versions=v1alpha1,name=vcomplianceexception.argus.io,admissionReviewVersions=v1
+
+// ComplianceExceptionCustomValidator rejects ComplianceExceptions that target no Component, reference a
+// Control or Assessment that does not exist, or expire in the past. Expired exceptions may still be updated,
+// as long as their expiry is left untouched.
+type ComplianceExceptionCustomValidator struct {
+	Client client.Reader
+}
+
+func (v *ComplianceExceptionCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	res, ok := obj.(*argusiov1alpha1.ComplianceException)
+	if !ok {
+		return nil, fmt.Errorf("expected a ComplianceException but got %T", obj)
+	}
+	err := lib.ValidateExpiry(res, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return nil, lib.Validate(ctx, v.Client, res)
+}
+
+func (v *ComplianceExceptionCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*argusiov1alpha1.ComplianceException)
+	if !ok {
+		return nil, fmt.Errorf("expected a ComplianceException but got %T", oldObj)
+	}
+	res, ok := newObj.(*argusiov1alpha1.ComplianceException)
+	if !ok {
+		return nil, fmt.Errorf("expected a ComplianceException but got %T", newObj)
+	}
+	if !res.Spec.ExpiresAt.Equal(&old.Spec.ExpiresAt) {
+		err := lib.ValidateExpiry(res, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, lib.Validate(ctx, v.Client, res)
+}
+
+func (v *ComplianceExceptionCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+var _ = Describe("ComplianceException Webhook", func() {
+	BeforeEach(func() {
+		err := k8sClient.Create(ctx, makeControl("exception-ctrl", "EXC-01", "1.0.0"))
+		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
+	})
+
+	It("accepts an exception of an existing Control", func() {
+		Expect(k8sClient.Create(ctx, makeComplianceException("valid-exception", "EXC-01", time.Now().Add(time.Hour)))).To(Succeed())
+	})
+
+	It("rejects a dangling controlRef", func() {
+		err := k8sClient.Create(ctx, makeComplianceException("no-control", "missing", time.Now().Add(time.Hour)))
+		Expect(err).To(MatchError(ContainSubstring("controlRef: no Control with code 'missing' and version '1.0.0'")))
+	})
+
+	It("rejects an exception that already expired", func() {
+		err := k8sClient.Create(ctx, makeComplianceException("expired", "EXC-01", time.Now().Add(-time.Hour)))
+		Expect(err).To(MatchError(ContainSubstring("expiresAt must be in the future")))
+	})
+
+	It("rejects an exception without targets", func() {
+		res := makeComplianceException("no-targets", "EXC-01", time.Now().Add(time.Hour))
+		res.Spec.ComponentRef = nil
+		err := k8sClient.Create(ctx, res)
+		Expect(err).To(MatchError(ContainSubstring("one of componentRef or componentSelector is required")))
+	})
+})
+
+func makeComplianceException(name, code string, expiresAt time.Time) *argusiov1alpha1.ComplianceException {
+	return &argusiov1alpha1.ComplianceException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: argusiov1alpha1.ComplianceExceptionSpec{
+			ControlRef: argusiov1alpha1.AssessmentControlDefinition{
+				Code:    code,
+				Version: "1.0.0",
+			},
+			ComponentRef:  []string{"component"},
+			Justification: "waiting on a vendor fix",
+			Approver:      "security",
+			ExpiresAt:     metav1.NewTime(expiresAt),
+		},
+	}
+}
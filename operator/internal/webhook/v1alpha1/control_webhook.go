@@ -0,0 +1,58 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/control"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+// SetupControlWebhookWithManager registers the Control webhook in the manager.
+func SetupControlWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argusiov1alpha1.Control{}).
+		WithValidator(&ControlCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argus-io-v1alpha1-control,mutating=false,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=controls,verbs=create;update,versions=v1alpha1,name=vcontrol.argus.io,admissionReviewVersions=v1
+
+// ControlCustomValidator rejects Controls that no Assessment could ever implement.
+type ControlCustomValidator struct{}
+
+func (v *ControlCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	req, ok := obj.(*argusiov1alpha1.Control)
+	if !ok {
+		return nil, fmt.Errorf("expected a Control but got %T", obj)
+	}
+	return nil, lib.Validate(req)
+}
+
+func (v *ControlCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *ControlCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+var _ = Describe("Control Webhook", func() {
+	It("accepts a valid Control", func() {
+		Expect(k8sClient.Create(ctx, makeControl("valid-ctrl", "CTRL-01", "1.0.0"))).To(Succeed())
+	})
+
+	It("rejects a Control without required Assessment classes", func() {
+		req := makeControl("no-classes", "CTRL-02", "1.0.0")
+		req.Spec.RequiredAssessmentClasses = []string{}
+		err := k8sClient.Create(ctx, req)
+		Expect(err).To(MatchError(ContainSubstring("requiredAssessmentClasses must list at least one class")))
+	})
+})
+
+func makeControl(name, code, version string) *argusiov1alpha1.Control {
+	return &argusiov1alpha1.Control{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: argusiov1alpha1.ControlSpec{
+			Definition: argusiov1alpha1.ControlDefinition{
+				Code:    code,
+				Version: version,
+			},
+			ApplicableComponentClasses: []string{"Database"},
+			RequiredAssessmentClasses:  []string{"Preventative"},
+		},
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	lib "github.com/ContainerSolutions/argus/operator/internal/framework"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+// SetupFrameworkWebhookWithManager registers the Framework webhook in the manager.
+func SetupFrameworkWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argusiov1alpha1.Framework{}).
+		WithValidator(&FrameworkCustomValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argus-io-v1alpha1-framework,mutating=false,failurePolicy=fail,sideEffects=None,groups=argus.io,resources=frameworks,verbs=create;update,versions=v1alpha1,name=vframework.argus.io,admissionReviewVersions=v1
+
+// FrameworkCustomValidator rejects Frameworks that list no Controls, or reference a Control that does not exist.
+type FrameworkCustomValidator struct {
+	Client client.Reader
+}
+
+func (v *FrameworkCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	res, ok := obj.(*argusiov1alpha1.Framework)
+	if !ok {
+		return nil, fmt.Errorf("expected a Framework but got %T", obj)
+	}
+	return nil, lib.Validate(ctx, v.Client, res)
+}
+
+func (v *FrameworkCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	res, ok := newObj.(*argusiov1alpha1.Framework)
+	if !ok {
+		return nil, fmt.Errorf("expected a Framework but got %T", newObj)
+	}
+	return nil, lib.Validate(ctx, v.Client, res)
+}
+
+func (v *FrameworkCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
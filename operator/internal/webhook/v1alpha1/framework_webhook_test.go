@@ -0,0 +1,71 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argusiov1alpha1 "github.com/ContainerSolutions/argus/operator/api/v1alpha1"
+)
+
+var _ = Describe("Framework Webhook", func() {
+	BeforeEach(func() {
+		err := k8sClient.Create(ctx, makeControl("framework-ctrl", "FW-01", "1.0.0"))
+		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
+	})
+
+	It("accepts a framework of existing Controls", func() {
+		Expect(k8sClient.Create(ctx, makeFramework("valid-framework", "FW-01"))).To(Succeed())
+	})
+
+	It("accepts a framework selecting Controls", func() {
+		res := makeFramework("selector-framework")
+		res.Spec.ControlSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"framework": "baseline"}}
+		Expect(k8sClient.Create(ctx, res)).To(Succeed())
+	})
+
+	It("rejects a dangling controlRef", func() {
+		err := k8sClient.Create(ctx, makeFramework("no-control", "FW-01", "missing"))
+		Expect(err).To(MatchError(ContainSubstring("controlRefs[1]: no Control with code 'missing' and version '1.0.0'")))
+	})
+
+	It("rejects a framework without Controls", func() {
+		err := k8sClient.Create(ctx, makeFramework("no-controls"))
+		Expect(err).To(MatchError(ContainSubstring("one of controlRefs or controlSelector is required")))
+	})
+})
+
+func makeFramework(name string, codes ...string) *argusiov1alpha1.Framework {
+	res := &argusiov1alpha1.Framework{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: argusiov1alpha1.FrameworkSpec{
+			Title:   "Baseline",
+			Version: "1.0.0",
+		},
+	}
+	for _, code := range codes {
+		res.Spec.ControlRefs = append(res.Spec.ControlRefs, argusiov1alpha1.AssessmentControlDefinition{Code: code, Version: "1.0.0"})
+	}
+	return res
+}